@@ -0,0 +1,293 @@
+package par
+
+// Collect produces a partial result from each partition of values using the
+// produce function, then merges the partial results into a single result
+// using the merge function.
+//
+// The partitions passed to produce are views into values, and the partial
+// results are merged in parallel, always merging the results of adjacent
+// partitions with the result of the earlier partition as the first argument,
+// so the merge function does not need to be commutative, only associative.
+//
+// If values is empty, produce is called once with the empty slice.
+func Collect[T, R any](values []T, produce func(chunk []T) R, merge func(R, R) R) R {
+	if len(values) == 0 {
+		return produce(values)
+	}
+
+	partitions, partitionSize := parts(values)
+	results := make([]R, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		results[p] = produce(values[start:end:end])
+	})
+
+	return mergeTree(results, merge)
+}
+
+// CountFunc returns the number of values for which the predicate returns
+// true.
+//
+// Each partition counts its matches, and the counts are summed, so unlike
+// with len(Filter(values, predicate)), no results slice is allocated.
+func CountFunc[T any](values []T, predicate func(T) bool) int {
+	if len(values) == 0 {
+		return 0
+	}
+
+	partitions, partitionSize := parts(values)
+	counts := make([]int, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		var count int
+		for i := start; i < end; i++ {
+			if predicate(values[i]) {
+				count++
+			}
+		}
+		counts[p] = count
+	})
+
+	var total int
+	for _, c := range counts {
+		total += c
+	}
+	return total
+}
+
+// Fold reduces the values to a single value of a possibly different type, by
+// repeatedly applying fold on an accumulator and each of the values.
+//
+// Each partition starts from a fresh accumulator returned by init and folds
+// its values into it in order, then the accumulators of the partitions are
+// merged in parallel with merge, always merging adjacent partitions with the
+// accumulator of the earlier partition as the first argument. The merge
+// function thus needs to be associative, but not commutative.
+//
+// If values is empty, the result of init is returned.
+func Fold[T, A any](values []T, init func() A, fold func(A, T) A, merge func(A, A) A) A {
+	return Aggregate(values, init, fold, merge)
+}
+
+// Aggregate is the general primitive for aggregating values using
+// per-partition state, upon which aggregations such as grouping, histograms
+// or top-k selection can be built.
+//
+// Each partition creates its own state with newState, so the state can be
+// freely mutated by accumulate without synchronization, and accumulates its
+// values into the state in order. The states of the partitions are then
+// combined in parallel, always combining adjacent partitions with the state
+// of the earlier partition as the first argument, so combine needs to be
+// associative, but not commutative. The combine function may mutate and
+// return either of its arguments.
+//
+// If values is empty, the result of newState is returned.
+func Aggregate[T, S any](values []T, newState func() S, accumulate func(S, T) S, combine func(S, S) S) S {
+	if len(values) == 0 {
+		return newState()
+	}
+
+	partitions, partitionSize := parts(values)
+	states := make([]S, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		state := newState()
+		for i := start; i < end; i++ {
+			state = accumulate(state, values[i])
+		}
+		states[p] = state
+	})
+
+	return mergeTree(states, combine)
+}
+
+// MapReduce applies the transform function on every item in values and
+// reduces the results to a single value by repeatedly applying combine, as
+// with Reduce(Map(values, transform), combine), but without allocating the
+// intermediate slice.
+//
+// Each partition reduces its transformed values in order as it maps them,
+// then the results of the partitions are combined in parallel, always
+// combining adjacent partitions with the result of the earlier partition as
+// the first argument.
+//
+// Panics if values is an empty slice.
+func MapReduce[In, Mid any](values []In, transform func(In) Mid, combine func(Mid, Mid) Mid) Mid {
+	if len(values) < 1 {
+		panic("cannot reduce an empty slice")
+	}
+
+	partitions, partitionSize := parts(values)
+	results := make([]Mid, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		v := transform(values[start])
+		for i := start + 1; i < end; i++ {
+			v = combine(v, transform(values[i]))
+		}
+		results[p] = v
+	})
+
+	return mergeTree(results, combine)
+}
+
+// FilterReduce reduces the values for which the predicate returns true to a
+// single value by repeatedly applying an accumulator, as with
+// Reduce(Filter(values, predicate), accumulator), but without allocating the
+// filtered slice. If no value matches, init is returned.
+//
+// Each partition reduces its matching values in order, then the results of
+// the partitions with matches are combined in parallel, always combining
+// adjacent partitions with the result of the earlier partition as the first
+// argument.
+func FilterReduce[T any](values []T, predicate func(T) bool, init T, accumulator func(T, T) T) T {
+	if len(values) == 0 {
+		return init
+	}
+
+	type partial struct {
+		value T
+		ok    bool
+	}
+	partitions, partitionSize := parts(values)
+	results := make([]partial, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		var r partial
+		for i := start; i < end; i++ {
+			if !predicate(values[i]) {
+				continue
+			}
+			if r.ok {
+				r.value = accumulator(r.value, values[i])
+			} else {
+				r = partial{values[i], true}
+			}
+		}
+		results[p] = r
+	})
+
+	r := mergeTree(results, func(a, b partial) partial {
+		if !a.ok {
+			return b
+		}
+		if !b.ok {
+			return a
+		}
+		return partial{accumulator(a.value, b.value), true}
+	})
+	if !r.ok {
+		return init
+	}
+	return r.value
+}
+
+// Aggregation is an aggregation that can be run together with other
+// aggregations over the same values using Aggregations. Aggregations are
+// created with NewAggregation.
+type Aggregation[T any] interface {
+	newPartial() aggregationPartial[T]
+}
+
+// aggregationPartial holds the state of an Aggregation for a partition.
+type aggregationPartial[T any] interface {
+	accumulate(value T)
+	combine(other aggregationPartial[T])
+	store()
+}
+
+// NewAggregation returns an Aggregation that aggregates the values in the same
+// way as Aggregate, and stores the result in dst when run.
+func NewAggregation[T, S any](dst *S, newState func() S, accumulate func(S, T) S, combine func(S, S) S) Aggregation[T] {
+	return &aggregation[T, S]{dst, newState, accumulate, combine}
+}
+
+// aggregation is the Aggregation returned by NewAggregation.
+type aggregation[T, S any] struct {
+	dst        *S
+	newState   func() S
+	accumulate func(S, T) S
+	combine    func(S, S) S
+}
+
+// newPartial returns the state of the aggregation for a new partition.
+func (a *aggregation[T, S]) newPartial() aggregationPartial[T] {
+	return &statePartial[T, S]{a, a.newState()}
+}
+
+// statePartial holds the state of an aggregation created by NewAggregation
+// for a partition.
+type statePartial[T, S any] struct {
+	aggregation *aggregation[T, S]
+	state       S
+}
+
+// accumulate accumulates value into the state.
+func (p *statePartial[T, S]) accumulate(value T) {
+	p.state = p.aggregation.accumulate(p.state, value)
+}
+
+// combine combines the state of a later partition into the state.
+func (p *statePartial[T, S]) combine(other aggregationPartial[T]) {
+	p.state = p.aggregation.combine(p.state, other.(*statePartial[T, S]).state)
+}
+
+// store stores the state in the destination of the aggregation.
+func (p *statePartial[T, S]) store() {
+	*p.aggregation.dst = p.state
+}
+
+// MultiAggregate runs multiple independent aggregations over the same values
+// in a single traversal. It is created with Aggregations.
+type MultiAggregate[T any] struct {
+	values       []T
+	aggregations []Aggregation[T]
+}
+
+// Aggregations returns a MultiAggregate over the values, to which the
+// aggregations to run can be added with Add.
+func Aggregations[T any](values []T) *MultiAggregate[T] {
+	return &MultiAggregate[T]{values: values}
+}
+
+// Add adds the aggregations to the aggregations to run, and returns m for
+// chaining.
+func (m *MultiAggregate[T]) Add(aggregations ...Aggregation[T]) *MultiAggregate[T] {
+	m.aggregations = append(m.aggregations, aggregations...)
+	return m
+}
+
+// Run runs the aggregations and stores their results in their destinations.
+//
+// Each partition accumulates every value into the state of each of the
+// aggregations in turn, so the values are traversed only once regardless of
+// the number of aggregations. The states of the partitions are then combined
+// as with Aggregate.
+func (m *MultiAggregate[T]) Run() {
+	if len(m.values) == 0 {
+		for _, a := range m.aggregations {
+			a.newPartial().store()
+		}
+		return
+	}
+
+	partitions, partitionSize := parts(m.values)
+	states := make([][]aggregationPartial[T], partitions)
+	forEachPart(partitions, partitionSize, len(m.values), func(p, start, end int) {
+		partials := make([]aggregationPartial[T], len(m.aggregations))
+		for a := range m.aggregations {
+			partials[a] = m.aggregations[a].newPartial()
+		}
+		for _, v := range m.values[start:end] {
+			for _, partial := range partials {
+				partial.accumulate(v)
+			}
+		}
+		states[p] = partials
+	})
+
+	results := mergeTree(states, func(a, b []aggregationPartial[T]) []aggregationPartial[T] {
+		for i := range a {
+			a[i].combine(b[i])
+		}
+		return a
+	})
+	for _, partial := range results {
+		partial.store()
+	}
+}
@@ -0,0 +1,291 @@
+package par_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jussi-kalliokoski/par"
+)
+
+func TestCollect(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				for i := range values {
+					values[i] = i
+				}
+
+				received := par.Collect(values, func(chunk []int) []int {
+					evens := []int(nil)
+					for _, v := range chunk {
+						if v%2 == 0 {
+							evens = append(evens, v)
+						}
+					}
+					return evens
+				}, func(a, b []int) []int {
+					return append(a, b...)
+				})
+
+				expected := []int(nil)
+				for _, v := range values {
+					if v%2 == 0 {
+						expected = append(expected, v)
+					}
+				}
+				assertSliceEquals(t, expected, received)
+			})
+		}
+	})
+}
+
+func TestCountFunc(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				var expected int
+				for i := range values {
+					values[i] = i
+					if i%3 == 0 {
+						expected++
+					}
+				}
+
+				received := par.CountFunc(values, func(v int) bool {
+					return v%3 == 0
+				})
+
+				assertEquals(t, expected, received)
+			})
+		}
+	})
+}
+
+func TestFold(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]string, l)
+				expected := make(map[string]int)
+				for i := range values {
+					values[i] = fmt.Sprint(i % 7)
+					expected[values[i]]++
+				}
+
+				received := par.Fold(values, func() map[string]int {
+					return make(map[string]int)
+				}, func(acc map[string]int, v string) map[string]int {
+					acc[v]++
+					return acc
+				}, func(a, b map[string]int) map[string]int {
+					for k, v := range b {
+						a[k] += v
+					}
+					return a
+				})
+
+				assertEquals(t, len(expected), len(received))
+				for k, v := range expected {
+					assertEquals(t, v, received[k])
+				}
+			})
+		}
+	})
+
+	t.Run("order", func(t *testing.T) {
+		values := make([]int, 1000)
+		for i := range values {
+			values[i] = i
+		}
+
+		received := par.Fold(values, func() []int {
+			return nil
+		}, func(acc []int, v int) []int {
+			return append(acc, v)
+		}, func(a, b []int) []int {
+			return append(a, b...)
+		})
+
+		assertSliceEquals(t, values, received)
+	})
+}
+
+func TestAggregate(t *testing.T) {
+	type stats struct {
+		count int
+		min   int
+		max   int
+	}
+
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				expected := &stats{min: 1 << 30, max: -1 << 30}
+				for i := range values {
+					values[i] = (i * 37) % 101
+					expected.count++
+					expected.min = minInt(expected.min, values[i])
+					expected.max = maxInt(expected.max, values[i])
+				}
+
+				received := par.Aggregate(values, func() *stats {
+					return &stats{min: 1 << 30, max: -1 << 30}
+				}, func(s *stats, v int) *stats {
+					s.count++
+					s.min = minInt(s.min, v)
+					s.max = maxInt(s.max, v)
+					return s
+				}, func(a, b *stats) *stats {
+					a.count += b.count
+					a.min = minInt(a.min, b.min)
+					a.max = maxInt(a.max, b.max)
+					return a
+				})
+
+				assertEquals(t, *expected, *received)
+			})
+		}
+	})
+}
+
+func TestMapReduce(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		t.Run("len 0", func(t *testing.T) {
+			assertPanics(t, func() {
+				par.MapReduce([]int(nil), func(v int) int {
+					return v
+				}, func(a, b int) int {
+					return a + b
+				})
+			})
+		})
+
+		for _, l := range testLengths(1) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				var expected string
+				for i := range values {
+					values[i] = i
+					expected += fmt.Sprint(i) + ","
+				}
+
+				received := par.MapReduce(values, func(v int) string {
+					return fmt.Sprint(v) + ","
+				}, func(a, b string) string {
+					return a + b
+				})
+
+				assertEquals(t, expected, received)
+			})
+		}
+	})
+}
+
+func TestFilterReduce(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				expected := -1
+				for i := range values {
+					values[i] = i
+					if i%3 == 1 {
+						if expected < 0 {
+							expected = 0
+						}
+						expected += i
+					}
+				}
+
+				received := par.FilterReduce(values, func(v int) bool {
+					return v%3 == 1
+				}, -1, func(a, b int) int {
+					return a + b
+				})
+
+				assertEquals(t, expected, received)
+			})
+		}
+	})
+}
+
+func TestAggregations(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				var expectedSum, expectedNegatives int
+				expectedOrder := []int(nil)
+				for i := range values {
+					values[i] = i%7 - 3
+					expectedSum += values[i]
+					if values[i] < 0 {
+						expectedNegatives++
+					}
+					expectedOrder = append(expectedOrder, values[i])
+				}
+
+				var sum, negatives int
+				var order []int
+				par.Aggregations(values).Add(
+					par.NewAggregation(&sum, func() int {
+						return 0
+					}, func(s, v int) int {
+						return s + v
+					}, func(a, b int) int {
+						return a + b
+					}),
+					par.NewAggregation(&negatives, func() int {
+						return 0
+					}, func(s, v int) int {
+						if v < 0 {
+							s++
+						}
+						return s
+					}, func(a, b int) int {
+						return a + b
+					}),
+				).Add(
+					par.NewAggregation(&order, func() []int {
+						return nil
+					}, func(s []int, v int) []int {
+						return append(s, v)
+					}, func(a, b []int) []int {
+						return append(a, b...)
+					}),
+				).Run()
+
+				assertEquals(t, expectedSum, sum)
+				assertEquals(t, expectedNegatives, negatives)
+				assertSliceEquals(t, expectedOrder, order)
+			})
+		}
+	})
+
+	t.Run("no aggregations", func(t *testing.T) {
+		par.Aggregations([]int{1, 2, 3}).Run()
+	})
+}
+
+func ExampleAggregations() {
+	values := []int{3, -1, 4, -1, 5}
+	add := func(a, b int) int { return a + b }
+	zero := func() int { return 0 }
+
+	var sum, negatives int
+	par.Aggregations(values).Add(
+		par.NewAggregation(&sum, zero, add, add),
+		par.NewAggregation(&negatives, zero, func(n, v int) int {
+			if v < 0 {
+				n++
+			}
+			return n
+		}, add),
+	).Run()
+
+	fmt.Println(sum, negatives)
+	// Output: 10 2
+}
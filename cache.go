@@ -0,0 +1,68 @@
+package par
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// BuildCache loads a value for each distinct key in keys using the load
+// function, and returns a map of the keys to the loaded values.
+//
+// The keys are deduplicated before loading, so load is called at most once
+// per distinct key. At most limit keys are loaded concurrently; if limit is
+// less than 1, runtime.GOMAXPROCS(0) is used as the limit.
+//
+// If load returns an error, no more keys are loaded, and the error of the
+// earliest key (in the order of keys) that failed is returned along with a
+// nil map. BuildCache always waits for all the started loads to finish before
+// returning.
+func BuildCache[K comparable, V any](keys []K, load func(K) (V, error), limit int) (map[K]V, error) {
+	seen := make(map[K]struct{}, len(keys))
+	distinct := make([]K, 0, len(keys))
+	for _, k := range keys {
+		if _, ok := seen[k]; !ok {
+			seen[k] = struct{}{}
+			distinct = append(distinct, k)
+		}
+	}
+
+	if limit < 1 {
+		limit = runtime.GOMAXPROCS(0)
+	}
+	workers := minInt(limit, len(distinct))
+	values := make([]V, len(distinct))
+	errs := make([]error, len(distinct))
+	var next int64
+	var failed int32
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for atomic.LoadInt32(&failed) == 0 {
+				i := int(atomic.AddInt64(&next, 1) - 1)
+				if i >= len(distinct) {
+					return
+				}
+				values[i], errs[i] = load(distinct[i])
+				if errs[i] != nil {
+					atomic.StoreInt32(&failed, 1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := make(map[K]V, len(distinct))
+	for i, k := range distinct {
+		result[k] = values[i]
+	}
+	return result, nil
+}
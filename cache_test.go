@@ -0,0 +1,90 @@
+package par_test
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/jussi-kalliokoski/par"
+)
+
+func TestBuildCache(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				keys := make([]int, l)
+				for i := range keys {
+					keys[i] = i % 10
+				}
+				var mu sync.Mutex
+				calls := make(map[int]int)
+
+				received, err := par.BuildCache(keys, func(k int) (string, error) {
+					mu.Lock()
+					calls[k]++
+					mu.Unlock()
+					return fmt.Sprint(k), nil
+				}, 3)
+
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				assertEquals(t, minInt(l, 10), len(received))
+				for _, k := range keys {
+					assertEquals(t, fmt.Sprint(k), received[k])
+					assertEquals(t, 1, calls[k])
+				}
+			})
+		}
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		keys := make([]int, 100)
+		for i := range keys {
+			keys[i] = i
+		}
+		var mu sync.Mutex
+		var active, maxActive int
+
+		_, err := par.BuildCache(keys, func(k int) (int, error) {
+			mu.Lock()
+			active++
+			if active > maxActive {
+				maxActive = active
+			}
+			mu.Unlock()
+			defer func() {
+				mu.Lock()
+				active--
+				mu.Unlock()
+			}()
+			return k, nil
+		}, 2)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertEquals(t, true, maxActive <= 2)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		keys := make([]int, 100)
+		for i := range keys {
+			keys[i] = i
+		}
+		expected := errors.New("load failed")
+
+		received, err := par.BuildCache(keys, func(k int) (int, error) {
+			if k == 50 {
+				return 0, expected
+			}
+			return k, nil
+		}, 0)
+
+		if err != expected {
+			t.Fatalf("expected error `%v`, got `%v`", expected, err)
+		}
+		assertEquals(t, 0, len(received))
+	})
+}
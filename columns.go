@@ -0,0 +1,102 @@
+package par
+
+import (
+	"reflect"
+)
+
+// ToColumns transposes a slice of structs into columns: one slice per
+// exported field of T, in the order of declaration. Each column is a []F
+// for the type F of the field, stored in an any, e.g. for a struct with the
+// fields `ID int` and `Name string` the result is []any{[]int, []string}.
+//
+// The fields are extracted using reflection, with each partition copying its
+// rows into every column in parallel. Unexported fields are not included.
+//
+// Panics if T is not a struct type.
+func ToColumns[T any](values []T) []any {
+	t := structType[T]()
+	fields := exportedFields(t)
+	columns := make([]reflect.Value, len(fields))
+	result := make([]any, len(fields))
+	for c, f := range fields {
+		columns[c] = reflect.MakeSlice(reflect.SliceOf(t.Field(f).Type), len(values), len(values))
+		result[c] = columns[c].Interface()
+	}
+	if len(values) == 0 || len(fields) == 0 {
+		return result
+	}
+
+	rows := reflect.ValueOf(values)
+	partitions, partitionSize := parts(values)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		for i := start; i < end; i++ {
+			row := rows.Index(i)
+			for c, f := range fields {
+				columns[c].Index(i).Set(row.Field(f))
+			}
+		}
+	})
+
+	return result
+}
+
+// FromColumns transposes columns as returned by ToColumns back into a slice of
+// structs. Unexported fields of the returned structs are left as zero values.
+//
+// Panics if T is not a struct type, or if the columns do not match the
+// exported fields of T in count, type or length.
+func FromColumns[T any](columns []any) []T {
+	t := structType[T]()
+	fields := exportedFields(t)
+	if len(columns) != len(fields) {
+		panic("number of columns does not match the number of exported fields")
+	}
+	cols := make([]reflect.Value, len(fields))
+	for c, f := range fields {
+		cols[c] = reflect.ValueOf(columns[c])
+		if cols[c].Type() != reflect.SliceOf(t.Field(f).Type) {
+			panic("column type does not match the type of the field")
+		}
+		if cols[c].Len() != cols[0].Len() {
+			panic("columns are of different lengths")
+		}
+	}
+	if len(cols) == 0 || cols[0].Len() == 0 {
+		return []T(nil)
+	}
+
+	n := cols[0].Len()
+	result := make([]T, n)
+	rows := reflect.ValueOf(result)
+	partitions, partitionSize := partsOf(n)
+	forEachPart(partitions, partitionSize, n, func(p, start, end int) {
+		for i := start; i < end; i++ {
+			row := rows.Index(i)
+			for c, f := range fields {
+				row.Field(f).Set(cols[c].Index(i))
+			}
+		}
+	})
+
+	return result
+}
+
+// structType returns the type of T, and panics if it is not a struct type.
+func structType[T any]() reflect.Type {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		panic("cannot transpose a type that is not a struct")
+	}
+	return t
+}
+
+// exportedFields returns the indices of the exported fields of t.
+func exportedFields(t reflect.Type) []int {
+	fields := []int(nil)
+	for f := 0; f < t.NumField(); f++ {
+		if t.Field(f).IsExported() {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
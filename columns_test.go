@@ -0,0 +1,72 @@
+package par_test
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/jussi-kalliokoski/par"
+)
+
+type columnsRow struct {
+	ID     int
+	Name   string
+	hidden bool
+	Score  float64
+}
+
+func TestToColumns(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]columnsRow, l)
+				ids := make([]int, l)
+				names := make([]string, l)
+				scores := make([]float64, l)
+				for i := range values {
+					values[i] = columnsRow{ID: i, Name: strconv.Itoa(i), hidden: true, Score: float64(i) / 2}
+					ids[i], names[i], scores[i] = values[i].ID, values[i].Name, values[i].Score
+				}
+
+				received := par.ToColumns(values)
+
+				assertEquals(t, 3, len(received))
+				assertSliceEquals(t, ids, received[0].([]int))
+				assertSliceEquals(t, names, received[1].([]string))
+				assertSliceEquals(t, scores, received[2].([]float64))
+
+				roundTrip := par.FromColumns[columnsRow](received)
+				for i := range values {
+					values[i].hidden = false
+				}
+				assertSliceEquals(t, values, roundTrip)
+			})
+		}
+	})
+
+	t.Run("not a struct", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.ToColumns([]int{1})
+		})
+	})
+}
+
+func TestFromColumns(t *testing.T) {
+	t.Run("column count mismatch", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.FromColumns[columnsRow]([]any{[]int{1}})
+		})
+	})
+
+	t.Run("column type mismatch", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.FromColumns[columnsRow]([]any{[]int{1}, []int{1}, []float64{1}})
+		})
+	})
+
+	t.Run("column length mismatch", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.FromColumns[columnsRow]([]any{[]int{1}, []string{"a", "b"}, []float64{1}})
+		})
+	})
+}
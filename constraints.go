@@ -0,0 +1,31 @@
+package par
+
+// Signed is a constraint for the signed integer types.
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Unsigned is a constraint for the unsigned integer types.
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Integer is a constraint for the integer types.
+type Integer interface {
+	Signed | Unsigned
+}
+
+// Float is a constraint for the floating-point types.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number is a constraint for the integer and floating-point types.
+type Number interface {
+	Integer | Float
+}
+
+// Ordered is a constraint for the types that support the < operator.
+type Ordered interface {
+	Integer | Float | ~string
+}
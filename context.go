@@ -0,0 +1,80 @@
+package par
+
+import (
+	"context"
+)
+
+// AnyContext returns a boolean indicating if predicate returns true for any
+// of the values.
+//
+// The predicate receives a context that is cancelled as soon as the result
+// is decided, i.e. when the predicate has returned true for any value, or
+// when ctx is cancelled. This allows nested work within the predicate, such
+// as nested par calls, to stop promptly once its result is no longer needed.
+// As with Any, a partition will terminate upon the first encountered value
+// for which the predicate returns true, or when the context is cancelled.
+//
+// Returns the error of ctx if ctx is cancelled before the result is decided.
+func AnyContext[T any](ctx context.Context, values []T, predicate func(context.Context, T) bool) (bool, error) {
+	if len(values) == 0 {
+		return false, ctx.Err()
+	}
+
+	parent := ctx
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	partitions, partitionSize := parts(values)
+	results := make([]bool, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		for i := start; i < end; i++ {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				if predicate(ctx, values[i]) {
+					// a predicate aborted by the cancellation of parent does
+					// not decide the result.
+					if parent.Err() == nil {
+						results[p] = true
+						cancel() // trigger early return of remaining processors.
+					}
+					return
+				}
+			}
+		}
+	})
+
+	for _, r := range results {
+		if r {
+			return true, nil
+		}
+	}
+	return false, parent.Err()
+}
+
+// AllContext returns a boolean indicating if predicate returns true for all
+// of the values.
+//
+// The predicate receives a context that is cancelled as soon as the result
+// is decided, i.e. when the predicate has returned false for any value, or
+// when ctx is cancelled.
+//
+// Returns the error of ctx if ctx is cancelled before the result is decided.
+func AllContext[T any](ctx context.Context, values []T, predicate func(context.Context, T) bool) (bool, error) {
+	found, err := AnyContext(ctx, values, func(ctx context.Context, v T) bool { return !predicate(ctx, v) })
+	return !found && err == nil, err
+}
+
+// NoneContext returns a boolean indicating if predicate returns true for
+// none of the values.
+//
+// The predicate receives a context that is cancelled as soon as the result
+// is decided, i.e. when the predicate has returned true for any value, or
+// when ctx is cancelled.
+//
+// Returns the error of ctx if ctx is cancelled before the result is decided.
+func NoneContext[T any](ctx context.Context, values []T, predicate func(context.Context, T) bool) (bool, error) {
+	found, err := AnyContext(ctx, values, predicate)
+	return !found && err == nil, err
+}
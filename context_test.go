@@ -0,0 +1,157 @@
+package par_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jussi-kalliokoski/par"
+)
+
+func TestAnyContext(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(1) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				t.Run("true", func(t *testing.T) {
+					values := make([]int, l)
+					for i := range values {
+						values[i] = i
+					}
+					rand.Seed(int64(l))
+					values[rand.Intn(l)] = l
+
+					received, err := par.AnyContext(context.Background(), values, func(ctx context.Context, v int) bool {
+						return v == l
+					})
+
+					assertNoError(t, err)
+					assertEquals(t, true, received)
+				})
+
+				t.Run("false", func(t *testing.T) {
+					values := make([]int, l)
+					for i := range values {
+						values[i] = i
+					}
+
+					received, err := par.AnyContext(context.Background(), values, func(ctx context.Context, v int) bool {
+						return v == l
+					})
+
+					assertNoError(t, err)
+					assertEquals(t, false, received)
+				})
+			})
+		}
+	})
+
+	t.Run("nested cancellation", func(t *testing.T) {
+		values := make([]int, 1000)
+		values[0] = 1
+
+		received, err := par.AnyContext(context.Background(), values, func(ctx context.Context, v int) bool {
+			if v == 1 {
+				return true
+			}
+			<-ctx.Done()
+			return false
+		})
+
+		assertNoError(t, err)
+		assertEquals(t, true, received)
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		received, err := par.AnyContext(ctx, make([]int, 1000), func(ctx context.Context, v int) bool {
+			return false
+		})
+
+		assertError(t, context.Canceled, err)
+		assertEquals(t, false, received)
+	})
+}
+
+func TestAllContext(t *testing.T) {
+	values := make([]int, 1000)
+	for i := range values {
+		values[i] = i
+	}
+
+	received, err := par.AllContext(context.Background(), values, func(ctx context.Context, v int) bool {
+		return v < 1000
+	})
+	assertNoError(t, err)
+	assertEquals(t, true, received)
+
+	received, err = par.AllContext(context.Background(), values, func(ctx context.Context, v int) bool {
+		return v < 999
+	})
+	assertNoError(t, err)
+	assertEquals(t, false, received)
+
+	t.Run("parent cancelled", func(t *testing.T) {
+		ctx, cancel := cancelOnFirstCall()
+
+		received, err := par.AllContext(ctx, values, func(ctx context.Context, v int) bool {
+			cancel()
+			<-ctx.Done()
+			return false
+		})
+
+		assertError(t, context.Canceled, err)
+		assertEquals(t, false, received)
+	})
+}
+
+func TestNoneContext(t *testing.T) {
+	values := make([]int, 1000)
+	for i := range values {
+		values[i] = i
+	}
+
+	received, err := par.NoneContext(context.Background(), values, func(ctx context.Context, v int) bool {
+		return v == 1000
+	})
+	assertNoError(t, err)
+	assertEquals(t, true, received)
+
+	received, err = par.NoneContext(context.Background(), values, func(ctx context.Context, v int) bool {
+		return v == 999
+	})
+	assertNoError(t, err)
+	assertEquals(t, false, received)
+
+	t.Run("parent cancelled", func(t *testing.T) {
+		ctx, cancel := cancelOnFirstCall()
+
+		received, err := par.NoneContext(ctx, values, func(ctx context.Context, v int) bool {
+			cancel()
+			<-ctx.Done()
+			return true
+		})
+
+		assertError(t, context.Canceled, err)
+		assertEquals(t, false, received)
+	})
+}
+
+// cancelOnFirstCall returns a context and a function that cancels it shortly
+// after the first call, while the predicates are blocked on the context.
+func cancelOnFirstCall() (context.Context, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var once sync.Once
+	return ctx, func() {
+		once.Do(func() {
+			go func() {
+				time.Sleep(10 * time.Millisecond)
+				cancel()
+			}()
+		})
+	}
+}
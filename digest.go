@@ -0,0 +1,191 @@
+package par
+
+import (
+	"math"
+	"sort"
+)
+
+// Quantiles returns estimates of the quantiles qs of the values, e.g. 0.5 for
+// the median or 0.99 for the 99th percentile, without sorting the values.
+//
+// Each partition summarizes its values into a merging t-digest, and the
+// digests of the partitions are then merged in parallel. The digest keeps the
+// values near the extremes at a higher resolution than the values near the
+// median, so the relative error of the estimates is smallest for the extreme
+// quantiles, which makes them well suited for e.g. latency percentiles. The
+// minimum and the maximum are exact.
+//
+// NaN values are ignored. If there are no other values, the estimates are
+// NaN.
+//
+// Panics if any of the quantiles is outside the range [0, 1].
+func Quantiles(values []float64, qs []float64) []float64 {
+	for _, q := range qs {
+		if !(q >= 0 && q <= 1) {
+			panic("cannot estimate a quantile outside the range [0, 1]")
+		}
+	}
+
+	var d digest
+	if len(values) > 0 {
+		partitions, partitionSize := parts(values)
+		digests := make([]digest, partitions)
+		forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+			var d digest
+			for _, v := range values[start:end] {
+				d.add(v)
+			}
+			d.flush()
+			digests[p] = d
+		})
+		d = mergeTree(digests, digest.merge)
+	}
+
+	result := make([]float64, len(qs))
+	for i, q := range qs {
+		result[i] = d.quantile(q)
+	}
+	return result
+}
+
+const (
+	// digestCompression bounds the number of centroids in a digest, trading
+	// memory for accuracy.
+	digestCompression = 200
+	// digestBufferSize is the number of values buffered in a digest before
+	// they are merged into the centroids.
+	digestBufferSize = 2048
+)
+
+// centroid is a cluster of values in a digest, summarized by the mean and
+// the number of the values.
+type centroid struct {
+	mean   float64
+	weight float64
+}
+
+// digest is a merging t-digest, as described by Dunning and Ertl in
+// "Computing Extremely Accurate Quantiles Using t-Digests".
+type digest struct {
+	centroids []centroid
+	buffer    []centroid
+	min       float64
+	max       float64
+}
+
+// add adds x to the buffer of the digest, merging the buffer into the
+// centroids when it is full.
+func (d *digest) add(x float64) {
+	if math.IsNaN(x) {
+		return
+	}
+	if len(d.centroids) == 0 && len(d.buffer) == 0 {
+		d.min, d.max = x, x
+	}
+	d.min = math.Min(d.min, x)
+	d.max = math.Max(d.max, x)
+	d.buffer = append(d.buffer, centroid{x, 1})
+	if len(d.buffer) == digestBufferSize {
+		d.flush()
+	}
+}
+
+// flush merges the buffer of the digest into the centroids.
+func (d *digest) flush() {
+	if len(d.buffer) == 0 {
+		return
+	}
+	d.centroids = compress(append(d.centroids, d.buffer...))
+	d.buffer = d.buffer[:0]
+}
+
+// merge returns the digest of the union of the values of d and o. Both
+// digests must be flushed.
+func (d digest) merge(o digest) digest {
+	if len(d.centroids) == 0 {
+		return o
+	}
+	if len(o.centroids) == 0 {
+		return d
+	}
+	centroids := make([]centroid, 0, len(d.centroids)+len(o.centroids))
+	centroids = append(append(centroids, d.centroids...), o.centroids...)
+	return digest{
+		centroids: compress(centroids),
+		min:       math.Min(d.min, o.min),
+		max:       math.Max(d.max, o.max),
+	}
+}
+
+// compress sorts the centroids by their means and merges adjacent centroids
+// as long as the merged centroids stay within the size limits given by the
+// scale function of the digest, reusing the storage of centroids.
+func compress(centroids []centroid) []centroid {
+	sort.Slice(centroids, func(i, j int) bool {
+		return centroids[i].mean < centroids[j].mean
+	})
+
+	var total float64
+	for _, c := range centroids {
+		total += c.weight
+	}
+
+	result := centroids[:1]
+	var before float64
+	limit := total * digestLimit(0)
+	for _, c := range centroids[1:] {
+		cur := &result[len(result)-1]
+		if before+cur.weight+c.weight <= limit {
+			cur.weight += c.weight
+			cur.mean += (c.mean - cur.mean) * c.weight / cur.weight
+			continue
+		}
+		before += cur.weight
+		limit = total * digestLimit(before/total)
+		result = append(result, c)
+	}
+	return result
+}
+
+// digestLimit returns the largest quantile that a centroid starting at the
+// quantile q may extend to, using the k1 scale function of the t-digest.
+func digestLimit(q float64) float64 {
+	k := digestCompression / (2 * math.Pi) * math.Asin(2*q-1)
+	if k+1 >= digestCompression/4 {
+		return 1
+	}
+	return (math.Sin((k+1)*2*math.Pi/digestCompression) + 1) / 2
+}
+
+// quantile returns the estimate of the quantile q of the values of a flushed
+// digest, interpolating linearly between the centers of the centroids.
+func (d digest) quantile(q float64) float64 {
+	if len(d.centroids) == 0 {
+		return math.NaN()
+	}
+
+	var total float64
+	for _, c := range d.centroids {
+		total += c.weight
+	}
+	t := q * total
+
+	first, last := d.centroids[0], d.centroids[len(d.centroids)-1]
+	if t < first.weight/2 {
+		return d.min + (first.mean-d.min)*t/(first.weight/2)
+	}
+	if t > total-last.weight/2 {
+		return d.max - (d.max-last.mean)*(total-t)/(last.weight/2)
+	}
+
+	center := first.weight / 2
+	for i := 1; i < len(d.centroids); i++ {
+		prev, next := d.centroids[i-1], d.centroids[i]
+		nextCenter := center + (prev.weight+next.weight)/2
+		if t <= nextCenter {
+			return prev.mean + (next.mean-prev.mean)*(t-center)/(nextCenter-center)
+		}
+		center = nextCenter
+	}
+	return last.mean
+}
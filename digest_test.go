@@ -0,0 +1,47 @@
+package par_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/jussi-kalliokoski/par"
+)
+
+func TestQuantiles(t *testing.T) {
+	qs := []float64{0, 0.01, 0.25, 0.5, 0.75, 0.99, 1}
+
+	t.Run("lengths", func(t *testing.T) {
+		t.Run("len 0", func(t *testing.T) {
+			for _, v := range par.Quantiles(nil, qs) {
+				assertEquals(t, true, math.IsNaN(v))
+			}
+		})
+
+		for _, l := range append(testLengths(1), 100000) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := rand.New(rand.NewSource(int64(l))).Perm(l)
+				floats := make([]float64, l)
+				for i, v := range values {
+					floats[i] = float64(v)
+				}
+
+				received := par.Quantiles(floats, qs)
+
+				assertEquals(t, len(qs), len(received))
+				assertEquals(t, 0.0, received[0])
+				assertEquals(t, float64(l-1), received[len(qs)-1])
+				for i, q := range qs {
+					assertApprox(t, q*float64(l-1), received[i], math.Max(1, 0.01*float64(l)))
+				}
+			})
+		}
+	})
+
+	t.Run("invalid quantile", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.Quantiles([]float64{1, 2}, []float64{1.5})
+		})
+	})
+}
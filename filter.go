@@ -0,0 +1,155 @@
+package par
+
+import (
+	"sync"
+)
+
+// FilterEqual returns a copy of the values slice with only the values that
+// are equal to v.
+//
+// The implementation works like Filter, but compares the values directly
+// instead of calling a predicate for each value.
+func FilterEqual[T comparable](values []T, v T) []T {
+	return filterComparable(values, v, true)
+}
+
+// FilterNotEqual returns a copy of the values slice without the values that
+// are equal to v.
+//
+// The implementation works like Filter, but compares the values directly
+// instead of calling a predicate for each value.
+func FilterNotEqual[T comparable](values []T, v T) []T {
+	return filterComparable(values, v, false)
+}
+
+// filterComparable returns a copy of the values for which comparing with v
+// for equality results in keep.
+func filterComparable[T comparable](values []T, v T, keep bool) []T {
+	if len(values) == 0 {
+		return []T(nil)
+	}
+
+	jobs, totalCount := filterMark(len(values), func(start, end int, bitmap []uint64) int {
+		var count int
+		for i := start; i < end; i++ {
+			if (values[i] == v) == keep {
+				pos := i - start
+				bitmap[pos/64] |= 1 << (pos % 64)
+				count++
+			}
+		}
+		return count
+	})
+
+	return filterCopy(jobs, totalCount, values)
+}
+
+// StablePartitionInPlace reorders the values in place so that the values for
+// which the predicate returns true precede the values for which it returns
+// false, and returns the number of the values for which it returns true, i.e.
+// the index at which the second group starts. Both groups maintain the order
+// of the original values.
+//
+// The implementation works like Filter: the values are marked in
+// per-partition bitmaps in parallel using the predicate, then both groups are
+// placed at their offsets in a single temporary buffer in parallel, and the
+// buffer is then copied back into values in parallel. The predicate is called
+// exactly once per value.
+func StablePartitionInPlace[T any](values []T, predicate func(T) bool) int {
+	if len(values) == 0 {
+		return 0
+	}
+
+	jobs, totalCount := filterMark(len(values), func(start, end int, bitmap []uint64) int {
+		var count int
+		for i := start; i < end; i++ {
+			if predicate(values[i]) {
+				pos := i - start
+				bitmap[pos/64] |= 1 << (pos % 64)
+				count++
+			}
+		}
+		return count
+	})
+
+	buf := make([]T, len(values))
+	var wg sync.WaitGroup
+	wg.Add(len(jobs))
+	for p := range jobs {
+		go func(j filterJob) {
+			defer wg.Done()
+			matched, unmatched := j.offset, totalCount+j.start-j.offset
+			for i := j.start; i < j.end; i++ {
+				pos := i - j.start
+				if (j.bitmap[pos/64] & (1 << (pos % 64))) > 0 {
+					buf[matched] = values[i]
+					matched++
+				} else {
+					buf[unmatched] = values[i]
+					unmatched++
+				}
+			}
+		}(jobs[p])
+	}
+	wg.Wait()
+
+	partitions, partitionSize := parts(values)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		copy(values[start:end], buf[start:end])
+	})
+
+	return totalCount
+}
+
+// Unique returns a copy of the values slice with only the first occurrence of
+// each distinct value, maintaining the order of the original values.
+//
+// Values are distinct as defined by the == operator, so values that are not
+// equal to themselves, such as floating-point NaNs, are all kept.
+//
+// Each partition records the index of the first occurrence of each of its
+// values into its own map, and the maps are then merged in parallel, keeping
+// the indices of the earlier partitions. The first occurrences are then
+// marked and copied into the result in parallel, as with Filter.
+func Unique[T comparable](values []T) []T {
+	if len(values) == 0 {
+		return []T(nil)
+	}
+
+	partitions, partitionSize := parts(values)
+	maps := make([]map[T]int, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		m := make(map[T]int)
+		for i := start; i < end; i++ {
+			if values[i] != values[i] {
+				continue
+			}
+			if _, ok := m[values[i]]; !ok {
+				m[values[i]] = i
+			}
+		}
+		maps[p] = m
+	})
+	first := mergeTree(maps, func(a, b map[T]int) map[T]int {
+		for v, i := range b {
+			if _, ok := a[v]; !ok {
+				a[v] = i
+			}
+		}
+		return a
+	})
+
+	jobs, totalCount := filterMark(len(values), func(start, end int, bitmap []uint64) int {
+		var count int
+		for i := start; i < end; i++ {
+			if values[i] != values[i] || first[values[i]] == i {
+				pos := i - start
+				bitmap[pos/64] |= 1 << (pos % 64)
+				count++
+			}
+		}
+		return count
+	})
+
+	return filterCopy(jobs, totalCount, values)
+}
@@ -0,0 +1,138 @@
+package par_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/jussi-kalliokoski/par"
+)
+
+func TestFilterEqual(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				expected := []int(nil)
+				for i := range values {
+					values[i] = i % 3
+					if values[i] == 1 {
+						expected = append(expected, values[i])
+					}
+				}
+
+				received := par.FilterEqual(values, 1)
+
+				assertSliceEquals(t, expected, received)
+			})
+		}
+	})
+}
+
+func TestFilterNotEqual(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				expected := []int(nil)
+				for i := range values {
+					values[i] = i % 3
+					if values[i] != 1 {
+						expected = append(expected, values[i])
+					}
+				}
+
+				received := par.FilterNotEqual(values, 1)
+
+				assertSliceEquals(t, expected, received)
+			})
+		}
+	})
+}
+
+func TestStablePartitionInPlace(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				matched := []int(nil)
+				unmatched := []int(nil)
+				for i := range values {
+					values[i] = (i * 7919) % 101
+					if values[i]%3 == 0 {
+						matched = append(matched, values[i])
+					} else {
+						unmatched = append(unmatched, values[i])
+					}
+				}
+
+				split := par.StablePartitionInPlace(values, func(v int) bool {
+					return v%3 == 0
+				})
+
+				assertEquals(t, len(matched), split)
+				assertSliceEquals(t, matched, values[:split])
+				assertSliceEquals(t, unmatched, values[split:])
+			})
+		}
+	})
+}
+
+func TestUnique(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				seen := make(map[int]bool)
+				expected := []int(nil)
+				for i := range values {
+					values[i] = (i * i) % 37
+					if !seen[values[i]] {
+						seen[values[i]] = true
+						expected = append(expected, values[i])
+					}
+				}
+
+				assertSliceEquals(t, expected, par.Unique(values))
+			})
+		}
+	})
+
+	t.Run("NaN", func(t *testing.T) {
+		received := par.Unique([]float64{1, math.NaN(), 2, math.NaN(), 1})
+
+		assertEquals(t, 4, len(received))
+		assertEquals(t, 1.0, received[0])
+		assertEquals(t, true, math.IsNaN(received[1]))
+		assertEquals(t, 2.0, received[2])
+		assertEquals(t, true, math.IsNaN(received[3]))
+	})
+}
+
+func BenchmarkFilterEqual(b *testing.B) {
+	rand.Seed(1)
+	values := make([]int32, 10000000)
+	for i := range values {
+		values[i] = rand.Int31n(4)
+	}
+
+	b.Run("filter", func(b *testing.B) {
+		var r bool
+		for n := 0; n < b.N; n++ {
+			result := par.Filter(values, func(v int32) bool {
+				return v == 1
+			})
+			r = len(result) == 123
+		}
+		deadBool = r
+	})
+	b.Run("filter equal", func(b *testing.B) {
+		var r bool
+		for n := 0; n < b.N; n++ {
+			result := par.FilterEqual(values, 1)
+			r = len(result) == 123
+		}
+		deadBool = r
+	})
+}
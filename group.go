@@ -0,0 +1,346 @@
+package par
+
+// TopKByGroup groups the values by the key returned by the key function, and
+// returns the first k values of each group in the order defined by less,
+// e.g. the top k values per group when less orders the best values first.
+//
+// The values of each group are returned in the order defined by less, with
+// ties broken by the order of the original values, so the result is
+// deterministic.
+//
+// Each partition keeps a bounded heap of k values per key, and the heaps of
+// each key are merged at the end, so at most k values per key per partition
+// are retained at any time.
+//
+// Panics if k is less than 1.
+func TopKByGroup[T any, K comparable](values []T, key func(T) K, k int, less func(a, b T) bool) map[K][]T {
+	if k < 1 {
+		panic("cannot keep less than one value per group")
+	}
+	result := make(map[K][]T)
+	if len(values) == 0 {
+		return result
+	}
+
+	itemLess := indexedLess(less)
+	partitions, partitionSize := parts(values)
+	heaps := make([]map[K]*boundedHeap[indexed[T]], partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		groups := make(map[K]*boundedHeap[indexed[T]])
+		for i := start; i < end; i++ {
+			kv := key(values[i])
+			h, ok := groups[kv]
+			if !ok {
+				h = newBoundedHeap(k, itemLess)
+				groups[kv] = h
+			}
+			h.push(indexed[T]{values[i], i})
+		}
+		heaps[p] = groups
+	})
+
+	merged := heaps[0]
+	for _, groups := range heaps[1:] {
+		for kv, h := range groups {
+			target, ok := merged[kv]
+			if !ok {
+				merged[kv] = h
+				continue
+			}
+			for _, item := range h.items {
+				target.push(item)
+			}
+		}
+	}
+
+	for kv, h := range merged {
+		items := h.sorted()
+		group := make([]T, len(items))
+		for i, item := range items {
+			group[i] = item.value
+		}
+		result[kv] = group
+	}
+	return result
+}
+
+// GroupBy groups the values by the key returned by the key function, and
+// returns a map of the keys to the values of each group. The values within
+// each group maintain the order of the original values.
+//
+// Each partition groups its values into its own map, then the groups of each
+// key are concatenated in the order of the partitions, with the keys divided
+// across partitions.
+func GroupBy[T any, K comparable](values []T, key func(T) K) map[K][]T {
+	_, groups := GroupByOrdered(values, key)
+	return groups
+}
+
+// GroupByOrdered works like GroupBy, but also returns the keys in the order
+// in which they first appear in values.
+func GroupByOrdered[T any, K comparable](values []T, key func(T) K) ([]K, map[K][]T) {
+	if len(values) == 0 {
+		return []K(nil), make(map[K][]T)
+	}
+
+	partitions, partitionSize := parts(values)
+	groups := make([]map[K][]T, partitions)
+	keys := make([][]K, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		g := make(map[K][]T)
+		for i := start; i < end; i++ {
+			k := key(values[i])
+			group, ok := g[k]
+			if !ok {
+				keys[p] = append(keys[p], k)
+			}
+			g[k] = append(group, values[i])
+		}
+		groups[p] = g
+	})
+
+	ordered := orderedKeys(keys)
+	return ordered, mergeGroups(groups, ordered)
+}
+
+// orderedKeys returns the distinct keys of the per-partition keys in the order
+// in which they first appear.
+func orderedKeys[K comparable](keys [][]K) []K {
+	seen := make(map[K]struct{}, len(keys[0]))
+	result := make([]K, 0, len(keys[0]))
+	for _, partitionKeys := range keys {
+		for _, k := range partitionKeys {
+			if _, ok := seen[k]; !ok {
+				seen[k] = struct{}{}
+				result = append(result, k)
+			}
+		}
+	}
+	return result
+}
+
+// mergeGroups returns a map of each of the keys to the concatenation of its
+// per-partition groups, concatenating the groups in parallel.
+func mergeGroups[T any, K comparable](groups []map[K][]T, keys []K) map[K][]T {
+	merged := make([][]T, len(keys))
+	partitions, partitionSize := parts(keys)
+	forEachPart(partitions, partitionSize, len(keys), func(p, start, end int) {
+		for i := start; i < end; i++ {
+			var total int
+			for _, g := range groups {
+				total += len(g[keys[i]])
+			}
+			group := make([]T, 0, total)
+			for _, g := range groups {
+				group = append(group, g[keys[i]]...)
+			}
+			merged[i] = group
+		}
+	})
+
+	result := make(map[K][]T, len(keys))
+	for i, k := range keys {
+		result[k] = merged[i]
+	}
+	return result
+}
+
+// GroupReduce groups the values by the key returned by the key function, and
+// reduces the values of each group to a single value by repeatedly applying
+// reduce, returning a map of the keys to the reduced values.
+//
+// Each partition reduces its values per key into its own map in order, then
+// the maps of the partitions are merged in parallel, always merging adjacent
+// partitions with the value of the earlier partition as the first argument,
+// so the per-key slices GroupBy would build are never materialized.
+func GroupReduce[T any, K comparable](values []T, key func(T) K, reduce func(acc, v T) T) map[K]T {
+	return reduceByKey(values, func(v T) (K, T) { return key(v), v }, reduce)
+}
+
+// reduceByKey returns a map of the keys to the values returned by the entry
+// function, reducing the values of duplicate keys in order with reduce.
+func reduceByKey[T any, K comparable, V any](values []T, entry func(T) (K, V), reduce func(V, V) V) map[K]V {
+	if len(values) == 0 {
+		return make(map[K]V)
+	}
+
+	partitions, partitionSize := parts(values)
+	maps := make([]map[K]V, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		m := make(map[K]V)
+		for i := start; i < end; i++ {
+			k, v := entry(values[i])
+			if acc, ok := m[k]; ok {
+				m[k] = reduce(acc, v)
+			} else {
+				m[k] = v
+			}
+		}
+		maps[p] = m
+	})
+
+	return mergeTree(maps, func(a, b map[K]V) map[K]V {
+		for k, v := range b {
+			if acc, ok := a[k]; ok {
+				a[k] = reduce(acc, v)
+			} else {
+				a[k] = v
+			}
+		}
+		return a
+	})
+}
+
+// Associate returns a map of the keys to the values returned by the fn
+// function for each of the values. If fn returns the same key for multiple
+// values, the value of the last one in the order of the original values wins.
+//
+// Each partition builds its own map, and the maps are merged in parallel in
+// the order of the partitions.
+func Associate[T any, K comparable, V any](values []T, fn func(T) (K, V)) map[K]V {
+	return reduceByKey(values, fn, func(_, v V) V { return v })
+}
+
+// AssociateWith returns a map of the keys returned by the key function to the
+// values returned by the value function for each of the values. If the key
+// function returns the same key for multiple values, their values are merged
+// with onConflict, which receives the value accumulated so far and the new
+// value.
+//
+// The merging is deterministic: the values of each key are merged in the
+// order of the original values, although not necessarily left to right, so
+// onConflict needs to be associative.
+func AssociateWith[T any, K comparable, V any](values []T, key func(T) K, value func(T) V, onConflict func(old, new V) V) map[K]V {
+	return reduceByKey(values, func(v T) (K, V) { return key(v), value(v) }, onConflict)
+}
+
+// CountBy returns a map of the keys returned by the key function to the
+// number of values with each key.
+//
+// Each partition counts its values into its own map, and the maps are then
+// merged in parallel.
+func CountBy[T any, K comparable](values []T, key func(T) K) map[K]int {
+	if len(values) == 0 {
+		return make(map[K]int)
+	}
+
+	partitions, partitionSize := parts(values)
+	counts := make([]map[K]int, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		c := make(map[K]int)
+		for i := start; i < end; i++ {
+			c[key(values[i])]++
+		}
+		counts[p] = c
+	})
+
+	return mergeTree(counts, func(a, b map[K]int) map[K]int {
+		if len(a) < len(b) {
+			a, b = b, a
+		}
+		for k, n := range b {
+			a[k] += n
+		}
+		return a
+	})
+}
+
+// ValueCount holds a value along with the number of its occurrences, as
+// returned by MostCommon.
+type ValueCount[T any] struct {
+	Value T
+	Count int
+}
+
+// MostCommon returns the n most frequent of the values along with their
+// counts, most frequent first. Ties are broken by the order of the first
+// occurrences of the values, so the result is deterministic. If there are
+// fewer than n distinct values, all of them are returned.
+//
+// Each partition counts its values into its own map, and the maps are then
+// merged in parallel as with CountBy. The most frequent values are then
+// selected in parallel using a bounded heap of n values per partition, so
+// the counts are never sorted.
+//
+// Panics if n is less than 1.
+func MostCommon[T comparable](values []T, n int) []ValueCount[T] {
+	if n < 1 {
+		panic("cannot select less than one value")
+	}
+	if len(values) == 0 {
+		return []ValueCount[T](nil)
+	}
+
+	type occurrences struct {
+		count int
+		first int
+	}
+	partitions, partitionSize := parts(values)
+	maps := make([]map[T]occurrences, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		m := make(map[T]occurrences)
+		for i := start; i < end; i++ {
+			o, ok := m[values[i]]
+			if !ok {
+				o.first = i
+			}
+			o.count++
+			m[values[i]] = o
+		}
+		maps[p] = m
+	})
+	counts := mergeTree(maps, func(a, b map[T]occurrences) map[T]occurrences {
+		for v, o := range b {
+			if acc, ok := a[v]; ok {
+				acc.count += o.count
+				a[v] = acc
+			} else {
+				a[v] = o
+			}
+		}
+		return a
+	})
+
+	entries := make([]indexed[ValueCount[T]], 0, len(counts))
+	for v, o := range counts {
+		entries = append(entries, indexed[ValueCount[T]]{ValueCount[T]{v, o.count}, o.first})
+	}
+
+	less := indexedLess(func(a, b ValueCount[T]) bool { return a.Count > b.Count })
+	partitions, partitionSize = parts(entries)
+	heaps := make([]*boundedHeap[indexed[ValueCount[T]]], partitions)
+	forEachPart(partitions, partitionSize, len(entries), func(p, start, end int) {
+		h := newBoundedHeap(n, less)
+		for _, e := range entries[start:end] {
+			h.push(e)
+		}
+		heaps[p] = h
+	})
+	for _, h := range heaps[1:] {
+		for _, e := range h.items {
+			heaps[0].push(e)
+		}
+	}
+
+	items := heaps[0].sorted()
+	result := make([]ValueCount[T], len(items))
+	for i, item := range items {
+		result[i] = item.value
+	}
+	return result
+}
+
+// Mode returns the most frequent of the values along with its count. Ties
+// are broken by the order of the first occurrences of the values, so the
+// result is deterministic.
+//
+// The implementation is the same as with MostCommon.
+//
+// Panics if values is an empty slice.
+func Mode[T comparable](values []T) (T, int) {
+	if len(values) < 1 {
+		panic("cannot find the mode of an empty slice")
+	}
+	mode := MostCommon(values, 1)[0]
+	return mode.Value, mode.Count
+}
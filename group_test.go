@@ -0,0 +1,284 @@
+package par_test
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/jussi-kalliokoski/par"
+)
+
+func TestTopKByGroup(t *testing.T) {
+	type item struct {
+		Group int
+		Score int
+		ID    int
+	}
+
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]item, l)
+				groups := make(map[int][]item)
+				for i := range values {
+					values[i] = item{Group: i % 5, Score: (i * 7) % 11, ID: i}
+					groups[values[i].Group] = append(groups[values[i].Group], values[i])
+				}
+				less := func(a, b item) bool {
+					return a.Score > b.Score
+				}
+
+				received := par.TopKByGroup(values, func(v item) int {
+					return v.Group
+				}, 3, less)
+
+				assertEquals(t, len(groups), len(received))
+				for g, group := range groups {
+					sort.SliceStable(group, func(i, j int) bool {
+						return less(group[i], group[j])
+					})
+					if len(group) > 3 {
+						group = group[:3]
+					}
+					assertSliceEquals(t, group, received[g])
+				}
+			})
+		}
+	})
+
+	t.Run("invalid k", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.TopKByGroup([]int{1}, func(v int) int { return v }, 0, func(a, b int) bool { return a < b })
+		})
+	})
+}
+
+func TestGroupBy(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				expected := make(map[int][]int)
+				for i := range values {
+					values[i] = i
+					expected[i%7] = append(expected[i%7], i)
+				}
+
+				received := par.GroupBy(values, func(v int) int {
+					return v % 7
+				})
+
+				assertEquals(t, len(expected), len(received))
+				for k, group := range expected {
+					assertSliceEquals(t, group, received[k])
+				}
+			})
+		}
+	})
+}
+
+func TestGroupByOrdered(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				expectedKeys := []int(nil)
+				expected := make(map[int][]int)
+				for i := range values {
+					values[i] = i
+					k := (i * 5) % 13
+					if _, ok := expected[k]; !ok {
+						expectedKeys = append(expectedKeys, k)
+					}
+					expected[k] = append(expected[k], i)
+				}
+
+				keys, received := par.GroupByOrdered(values, func(v int) int {
+					return (v * 5) % 13
+				})
+
+				assertSliceEquals(t, expectedKeys, keys)
+				assertEquals(t, len(expected), len(received))
+				for k, group := range expected {
+					assertSliceEquals(t, group, received[k])
+				}
+			})
+		}
+	})
+}
+
+func TestGroupReduce(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]string, l)
+				expected := make(map[byte]string)
+				for i := range values {
+					values[i] = fmt.Sprint(i)
+					k := values[i][len(values[i])-1]
+					if acc, ok := expected[k]; ok {
+						expected[k] = acc + "," + values[i]
+					} else {
+						expected[k] = values[i]
+					}
+				}
+
+				received := par.GroupReduce(values, func(v string) byte {
+					return v[len(v)-1]
+				}, func(acc, v string) string {
+					return acc + "," + v
+				})
+
+				assertEquals(t, len(expected), len(received))
+				for k, v := range expected {
+					assertEquals(t, v, received[k])
+				}
+			})
+		}
+	})
+}
+
+func TestAssociate(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				expected := make(map[int]int)
+				for i := range values {
+					values[i] = i
+					expected[i%10] = i
+				}
+
+				received := par.Associate(values, func(v int) (int, int) {
+					return v % 10, v
+				})
+
+				assertEquals(t, len(expected), len(received))
+				for k, v := range expected {
+					assertEquals(t, v, received[k])
+				}
+			})
+		}
+	})
+}
+
+func TestAssociateWith(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				expected := make(map[int][]int)
+				for i := range values {
+					values[i] = i
+					expected[i%10] = append(expected[i%10], i*2)
+				}
+
+				received := par.AssociateWith(values, func(v int) int {
+					return v % 10
+				}, func(v int) []int {
+					return []int{v * 2}
+				}, func(old, new []int) []int {
+					return append(old, new...)
+				})
+
+				assertEquals(t, len(expected), len(received))
+				for k, v := range expected {
+					assertSliceEquals(t, v, received[k])
+				}
+			})
+		}
+	})
+}
+
+func TestCountBy(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				expected := make(map[int]int)
+				for i := range values {
+					values[i] = (i * i) % 17
+					expected[values[i]]++
+				}
+
+				received := par.CountBy(values, func(v int) int {
+					return v
+				})
+
+				assertEquals(t, len(expected), len(received))
+				for k, v := range expected {
+					assertEquals(t, v, received[k])
+				}
+			})
+		}
+	})
+}
+
+func TestMostCommon(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				counts := make(map[int]int)
+				order := []int(nil)
+				for i := range values {
+					values[i] = (i * i) % 17
+					if counts[values[i]] == 0 {
+						order = append(order, values[i])
+					}
+					counts[values[i]]++
+				}
+				expected := []par.ValueCount[int](nil)
+				for _, v := range order {
+					expected = append(expected, par.ValueCount[int]{Value: v, Count: counts[v]})
+				}
+				sort.SliceStable(expected, func(i, j int) bool {
+					return expected[i].Count > expected[j].Count
+				})
+				expected = expected[:minInt(3, len(expected))]
+
+				received := par.MostCommon(values, 3)
+
+				assertSliceEquals(t, expected, received)
+			})
+		}
+	})
+
+	t.Run("invalid n", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.MostCommon([]int{1, 2}, 0)
+		})
+	})
+}
+
+func TestMode(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		t.Run("len 0", func(t *testing.T) {
+			assertPanics(t, func() {
+				par.Mode([]int(nil))
+			})
+		})
+
+		for _, l := range testLengths(1) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				counts := make(map[int]int)
+				var expectedValue, expectedCount int
+				for i := range values {
+					values[i] = (i * i) % 17
+					counts[values[i]]++
+				}
+				for _, v := range values {
+					if counts[v] > expectedCount {
+						expectedValue, expectedCount = v, counts[v]
+					}
+				}
+
+				value, count := par.Mode(values)
+
+				assertEquals(t, expectedValue, value)
+				assertEquals(t, expectedCount, count)
+			})
+		}
+	})
+}
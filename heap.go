@@ -0,0 +1,93 @@
+package par
+
+// indexed holds a value along with its index in the original values.
+type indexed[T any] struct {
+	value T
+	index int
+}
+
+// boundedHeap holds up to k items, keeping the ones that are first in the
+// order defined by less when more items are pushed. The heap is ordered so
+// that the root is the last of the kept items, so it is the item to evict.
+type boundedHeap[T any] struct {
+	items []T
+	k     int
+	less  func(a, b T) bool
+}
+
+// newBoundedHeap returns an empty heap that keeps the first k items in the
+// order defined by less.
+func newBoundedHeap[T any](k int, less func(a, b T) bool) *boundedHeap[T] {
+	return &boundedHeap[T]{k: k, less: less}
+}
+
+// push adds v to the heap if the heap is not full, or if v is before the last
+// kept item, in which case the last kept item is evicted.
+func (h *boundedHeap[T]) push(v T) {
+	if len(h.items) < h.k {
+		h.items = append(h.items, v)
+		h.up(len(h.items) - 1)
+		return
+	}
+	if h.k > 0 && h.less(v, h.items[0]) {
+		h.items[0] = v
+		h.down(0)
+	}
+}
+
+// up moves the item at index j up the heap until the heap is ordered.
+func (h *boundedHeap[T]) up(j int) {
+	for j > 0 {
+		parent := (j - 1) / 2
+		if !h.less(h.items[parent], h.items[j]) {
+			return
+		}
+		h.items[parent], h.items[j] = h.items[j], h.items[parent]
+		j = parent
+	}
+}
+
+// down moves the item at index i down the heap until the heap is ordered.
+func (h *boundedHeap[T]) down(i int) {
+	for {
+		c := 2*i + 1
+		if c >= len(h.items) {
+			return
+		}
+		if c+1 < len(h.items) && h.less(h.items[c], h.items[c+1]) {
+			c++
+		}
+		if !h.less(h.items[i], h.items[c]) {
+			return
+		}
+		h.items[i], h.items[c] = h.items[c], h.items[i]
+		i = c
+	}
+}
+
+// sorted returns the kept items in the order defined by less, emptying the
+// heap.
+func (h *boundedHeap[T]) sorted() []T {
+	result := make([]T, len(h.items))
+	for i := len(result) - 1; i >= 0; i-- {
+		result[i] = h.items[0]
+		h.items[0] = h.items[len(h.items)-1]
+		h.items = h.items[:len(h.items)-1]
+		h.down(0)
+	}
+	return result
+}
+
+// indexedLess returns a less function for indexed values that orders by less,
+// breaking ties by the lower index.
+func indexedLess[T any](less func(a, b T) bool) func(a, b indexed[T]) bool {
+	return func(a, b indexed[T]) bool {
+		if less(a.value, b.value) {
+			return true
+		}
+		if less(b.value, a.value) {
+			return false
+		}
+		return a.index < b.index
+	}
+}
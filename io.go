@@ -0,0 +1,146 @@
+package par
+
+import (
+	"bytes"
+	"fmt"
+	"hash"
+	"io"
+)
+
+// WriteAll encodes every item in values using the encode function and writes
+// the encoded items to w in the order of the original values.
+//
+// The items are encoded in parallel into per-partition buffers, and the
+// buffers are written to w in order as soon as the partition and all the
+// partitions before it have been encoded, so writing can start before all of
+// the values have been encoded.
+//
+// Returns the first error returned by w, in which case the remaining buffers
+// are not written. WriteAll always waits for all the encoding to finish before
+// returning.
+func WriteAll[T any](w io.Writer, values []T, encode func(T) []byte) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	partitions, partitionSize := parts(values)
+	buffers := make([][]byte, partitions)
+	ready := make([]chan struct{}, partitions)
+	for p := range ready {
+		ready[p] = make(chan struct{})
+	}
+	go forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		defer close(ready[p])
+		var buf []byte
+		for i := start; i < end; i++ {
+			buf = append(buf, encode(values[i])...)
+		}
+		buffers[p] = buf
+	})
+
+	var err error
+	for p := range buffers {
+		<-ready[p]
+		if err == nil {
+			_, err = w.Write(buffers[p])
+		}
+		buffers[p] = nil
+	}
+	return err
+}
+
+// ProcessRecords returns a slice of type Out by applying the transform
+// function on every record in data, without first copying the records into
+// separate slices.
+//
+// The nextBoundary function is used to split data into records: it receives
+// the remaining data and returns the index of the start of the next record,
+// i.e. the index just past the end of the first record including its
+// delimiter, or -1 if the data does not contain a record boundary. The
+// records passed to transform include their delimiters, and the last record
+// may lack one if data does not end in a boundary.
+//
+// The data is divided into partitions at approximate offsets, which are then
+// moved forward to the next record boundary using nextBoundary, so
+// nextBoundary must be able to find the next boundary starting from the
+// middle of a record, as is the case with e.g. newline-delimited records.
+//
+// The implementation is deterministic, and the returned slice maintains the
+// order of the records.
+func ProcessRecords[Out any](data []byte, nextBoundary func(data []byte) int, transform func(record []byte) Out) []Out {
+	if len(data) == 0 {
+		return []Out(nil)
+	}
+
+	partitions, partitionSize := parts(data)
+	starts := make([]int, partitions+1)
+	starts[partitions] = len(data)
+	for p := 1; p < partitions; p++ {
+		start := len(data)
+		if offset := maxInt(p*partitionSize, starts[p-1]); offset < len(data) {
+			if b := nextBoundary(data[offset:]); b >= 0 {
+				start = offset + b
+			}
+		}
+		starts[p] = start
+	}
+
+	results := make([][]Out, partitions)
+	forEachPart(partitions, partitionSize, len(data), func(p, _, _ int) {
+		out := []Out(nil)
+		end := starts[p+1]
+		for pos := starts[p]; pos < end; {
+			next := end
+			if b := nextBoundary(data[pos:end]); b > 0 {
+				next = pos + b
+			}
+			out = append(out, transform(data[pos:next:next]))
+			pos = next
+		}
+		results[p] = out
+	})
+
+	return Concat(results)
+}
+
+// VerifyChunks divides data into consecutive chunks of chunkSize bytes, where
+// the last chunk may be shorter, and returns the indices of the chunks whose
+// checksum, computed with a hash created by h, does not match the checksum at
+// the same index in sums.
+//
+// The chunks are verified in parallel, with one hash created per partition
+// and reset between the chunks. The returned indices are in ascending order.
+//
+// Returns an error if the number of sums does not match the number of chunks.
+//
+// Panics if chunkSize is less than 1.
+func VerifyChunks(data []byte, chunkSize int, sums [][]byte, h func() hash.Hash) ([]int, error) {
+	if chunkSize < 1 {
+		panic("cannot verify chunks with a size less than one")
+	}
+	chunks := Chunk(data, chunkSize)
+	if len(sums) != len(chunks) {
+		return nil, fmt.Errorf("expected %d checksums, got %d", len(chunks), len(sums))
+	}
+
+	if len(chunks) == 0 {
+		return []int(nil), nil
+	}
+
+	partitions, partitionSize := parts(chunks)
+	corrupt := make([][]int, partitions)
+	forEachPart(partitions, partitionSize, len(chunks), func(p, start, end int) {
+		hasher := h()
+		var sum []byte
+		for c := start; c < end; c++ {
+			hasher.Reset()
+			hasher.Write(chunks[c])
+			sum = hasher.Sum(sum[:0])
+			if !bytes.Equal(sum, sums[c]) {
+				corrupt[p] = append(corrupt[p], c)
+			}
+		}
+	})
+
+	return Concat(corrupt), nil
+}
@@ -0,0 +1,146 @@
+package par_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/jussi-kalliokoski/par"
+)
+
+func TestWriteAll(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				var expected bytes.Buffer
+				for i := range values {
+					values[i] = i
+					expected.WriteString(strconv.Itoa(i) + "\n")
+				}
+
+				var received bytes.Buffer
+				err := par.WriteAll(&received, values, func(v int) []byte {
+					return []byte(strconv.Itoa(v) + "\n")
+				})
+
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				assertEquals(t, expected.String(), received.String())
+			})
+		}
+	})
+
+	t.Run("write error", func(t *testing.T) {
+		values := make([]int, 1000)
+		expected := errors.New("write failed")
+		var calls int
+
+		err := par.WriteAll(writerFunc(func(b []byte) (int, error) {
+			calls++
+			return 0, expected
+		}), values, func(v int) []byte {
+			return []byte{byte(v)}
+		})
+
+		if err != expected {
+			t.Fatalf("expected error `%v`, got `%v`", expected, err)
+		}
+		assertEquals(t, 1, calls)
+	})
+}
+
+type writerFunc func([]byte) (int, error)
+
+func (fn writerFunc) Write(b []byte) (int, error) {
+	return fn(b)
+}
+
+func TestProcessRecords(t *testing.T) {
+	nextLine := func(data []byte) int {
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			return i + 1
+		}
+		return -1
+	}
+
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				var data []byte
+				expected := []string(nil)
+				for i := 0; i < l; i++ {
+					record := strconv.Itoa(i*i) + "\n"
+					data = append(data, record...)
+					expected = append(expected, record)
+				}
+
+				received := par.ProcessRecords(data, nextLine, func(record []byte) string {
+					return string(record)
+				})
+
+				assertSliceEquals(t, expected, received)
+			})
+		}
+	})
+
+	t.Run("no trailing delimiter", func(t *testing.T) {
+		received := par.ProcessRecords([]byte("a\nbb\nccc"), nextLine, func(record []byte) string {
+			return string(record)
+		})
+
+		assertSliceEquals(t, []string{"a\n", "bb\n", "ccc"}, received)
+	})
+
+	t.Run("single record", func(t *testing.T) {
+		received := par.ProcessRecords([]byte("abcdefghijklmnopqrstuvwxyz"), nextLine, func(record []byte) string {
+			return string(record)
+		})
+
+		assertSliceEquals(t, []string{"abcdefghijklmnopqrstuvwxyz"}, received)
+	})
+}
+
+func TestVerifyChunks(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				data := make([]byte, l)
+				for i := range data {
+					data[i] = byte(i)
+				}
+				sums := [][]byte(nil)
+				for _, chunk := range par.Chunk(data, 10) {
+					sum := sha256.Sum256(chunk)
+					sums = append(sums, sum[:])
+				}
+				expected := []int(nil)
+				for c := 1; c < len(sums); c += 3 {
+					data[c*10] ^= 0xff
+					expected = append(expected, c)
+				}
+
+				received, err := par.VerifyChunks(data, 10, sums, sha256.New)
+
+				assertNoError(t, err)
+				assertSliceEquals(t, expected, received)
+			})
+		}
+	})
+
+	t.Run("invalid chunk size", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.VerifyChunks([]byte{1}, 0, nil, sha256.New)
+		})
+	})
+
+	t.Run("checksum count mismatch", func(t *testing.T) {
+		_, err := par.VerifyChunks([]byte{1, 2, 3}, 2, [][]byte{nil}, sha256.New)
+
+		assertEquals(t, true, err != nil)
+	})
+}
@@ -0,0 +1,61 @@
+package par
+
+import (
+	"math"
+)
+
+// MinHash returns the MinHash signature of numHashes values for each of the
+// sets, for estimating the Jaccard similarity of the sets: the fraction of
+// positions at which the signatures of two sets are equal approximates the
+// similarity of the sets.
+//
+// Each hash function of the signature is a seeded 64-bit mix function, and
+// the seeds are fixed, so the signatures are deterministic and comparable
+// across calls. The signature of an empty set consists of math.MaxUint64
+// values. The returned signatures share a single allocation, but do not share
+// capacity, so appending to one does not overwrite another.
+//
+// Panics if numHashes is less than 1.
+func MinHash(sets [][]uint64, numHashes int) [][]uint64 {
+	if numHashes < 1 {
+		panic("cannot compute a signature of less than one hash")
+	}
+	if len(sets) == 0 {
+		return [][]uint64(nil)
+	}
+
+	seeds := make([]uint64, numHashes)
+	for h := range seeds {
+		seeds[h] = mix64(uint64(h) + 1)
+	}
+
+	partitions, partitionSize := parts(sets)
+	buf := make([]uint64, len(sets)*numHashes)
+	result := make([][]uint64, len(sets))
+	forEachPart(partitions, partitionSize, len(sets), func(p, start, end int) {
+		for s := start; s < end; s++ {
+			signature := buf[s*numHashes : (s+1)*numHashes : (s+1)*numHashes]
+			for h := range signature {
+				signature[h] = math.MaxUint64
+			}
+			for _, v := range sets[s] {
+				for h, seed := range seeds {
+					if x := mix64(v ^ seed); x < signature[h] {
+						signature[h] = x
+					}
+				}
+			}
+			result[s] = signature
+		}
+	})
+
+	return result
+}
+
+// mix64 returns a well-distributed hash of x using the SplitMix64 finalizer.
+func mix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
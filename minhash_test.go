@@ -0,0 +1,63 @@
+package par_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/jussi-kalliokoski/par"
+)
+
+func TestMinHash(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				sets := make([][]uint64, l)
+				for i := range sets {
+					for v := 0; v < i%20; v++ {
+						sets[i] = append(sets[i], uint64(v))
+					}
+				}
+
+				received := par.MinHash(sets, 16)
+
+				assertEquals(t, l, len(received))
+				for i := range received {
+					assertEquals(t, 16, len(received[i]))
+					// sets with the same items have equal signatures.
+					if i >= 20 {
+						assertSliceEquals(t, received[i-20], received[i])
+					}
+				}
+			})
+		}
+	})
+
+	t.Run("similarity", func(t *testing.T) {
+		a := make([]uint64, 1000)
+		b := make([]uint64, 1000)
+		for i := range a {
+			a[i] = uint64(i)
+			b[i] = uint64(i + 500)
+		}
+
+		received := par.MinHash([][]uint64{a, b, nil}, 256)
+
+		var equal int
+		for h := range received[0] {
+			if received[0][h] == received[1][h] {
+				equal++
+			}
+			assertEquals(t, uint64(math.MaxUint64), received[2][h])
+		}
+		similarity := float64(equal) / 256
+		// the exact Jaccard similarity is 500 / 1500.
+		assertEquals(t, true, math.Abs(similarity-1.0/3) < 0.1)
+	})
+
+	t.Run("invalid number of hashes", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.MinHash([][]uint64{{1}}, 0)
+		})
+	})
+}
@@ -0,0 +1,404 @@
+package par
+
+import (
+	"math"
+)
+
+// ScatterAdd adds each item in src to the item in dst at the index given by
+// the item at the same index in indices, i.e. dst[indices[i]] += src[i].
+//
+// Each partition accumulates into a private copy of dst, and the copies are
+// then summed into dst in parallel, so no synchronization is needed even when
+// indices contains duplicates. This requires allocating a copy of dst per
+// partition, so it is best suited for a dst that is small compared to src.
+//
+// Panics if indices and src are of different lengths, or if any of the
+// indices is out of the bounds of dst.
+func ScatterAdd[N Number](dst []N, indices []int, src []N) {
+	if len(indices) != len(src) {
+		panic("cannot scatter-add values with indices of a different length")
+	}
+	if len(src) == 0 {
+		return
+	}
+
+	partitions, partitionSize := parts(src)
+	bins := make([]N, partitions*len(dst))
+	outOfBounds := make([]bool, partitions)
+	forEachPart(partitions, partitionSize, len(src), func(p, start, end int) {
+		b := bins[p*len(dst) : (p+1)*len(dst)]
+		for i := start; i < end; i++ {
+			if uint(indices[i]) >= uint(len(b)) {
+				outOfBounds[p] = true
+				return
+			}
+			b[indices[i]] += src[i]
+		}
+	})
+	for _, o := range outOfBounds {
+		if o {
+			panic("scatter-add index out of bounds")
+		}
+	}
+
+	mergePartitions, mergePartitionSize := parts(dst)
+	forEachPart(mergePartitions, mergePartitionSize, len(dst), func(_, start, end int) {
+		for p := 0; p < partitions; p++ {
+			b := bins[p*len(dst) : (p+1)*len(dst)]
+			for j := start; j < end; j++ {
+				dst[j] += b[j]
+			}
+		}
+	})
+}
+
+// Score returns the result of applying the model on every row, e.g. for
+// batch inference over feature rows.
+//
+// The implementation is deterministic, and the returned slice maintains the
+// order of the original rows.
+func Score(rows [][]float64, model func([]float64) float64) []float64 {
+	return Map(rows, model)
+}
+
+// ScoreBatch returns the result of applying a vectorized model on every row.
+// The model is called once per partition with a view of the rows of the
+// partition, and must return one score per row.
+//
+// The implementation is deterministic, and the returned slice maintains the
+// order of the original rows.
+//
+// Panics if the model returns a different number of scores than it was given
+// rows.
+func ScoreBatch(rows [][]float64, model func([][]float64) []float64) []float64 {
+	if len(rows) == 0 {
+		return []float64(nil)
+	}
+
+	partitions, partitionSize := parts(rows)
+	result := make([]float64, len(rows))
+	mismatch := make([]bool, partitions)
+	forEachPart(partitions, partitionSize, len(rows), func(p, start, end int) {
+		scores := model(rows[start:end:end])
+		if len(scores) != end-start {
+			mismatch[p] = true
+			return
+		}
+		copy(result[start:end], scores)
+	})
+	for _, m := range mismatch {
+		if m {
+			panic("model returned a different number of scores than rows")
+		}
+	}
+
+	return result
+}
+
+// SumBy returns the sum of the results of applying fn on every item in
+// values, without allocating an intermediate slice of the results.
+//
+// Each partition sums its results in order, and the partition sums are then
+// added in the order of the partitions, so for a given number of partitions
+// the result is deterministic, even for floating-point numbers.
+func SumBy[T any, N Number](values []T, fn func(T) N) N {
+	if len(values) == 0 {
+		return 0
+	}
+
+	partitions, partitionSize := parts(values)
+	sums := make([]N, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		var sum N
+		for i := start; i < end; i++ {
+			sum += fn(values[i])
+		}
+		sums[p] = sum
+	})
+
+	var total N
+	for _, s := range sums {
+		total += s
+	}
+	return total
+}
+
+// Sum returns the sum of the values, or zero if values is empty.
+//
+// Each partition sums its values in order, and the partition sums are then
+// added in the order of the partitions, so for a given number of partitions
+// the result is deterministic, even for floating-point numbers.
+func Sum[N Number](values []N) N {
+	if len(values) == 0 {
+		return 0
+	}
+
+	partitions, partitionSize := parts(values)
+	sums := make([]N, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		var sum N
+		for _, v := range values[start:end] {
+			sum += v
+		}
+		sums[p] = sum
+	})
+
+	var total N
+	for _, s := range sums {
+		total += s
+	}
+	return total
+}
+
+// SumFloat returns the sum of the values using compensated summation, which
+// keeps track of the low-order bits lost in each addition, so the error of
+// the result does not grow with the number of values, unlike with Sum.
+//
+// Each partition sums its values with the Neumaier variant of Kahan
+// summation, and the compensated partition sums are then merged in the order
+// of the partitions, carrying the compensations across.
+func SumFloat(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	partitions, partitionSize := parts(values)
+	sums := make([]compensatedSum, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		var sum compensatedSum
+		for _, v := range values[start:end] {
+			sum = sum.add(v)
+		}
+		sums[p] = sum
+	})
+
+	var total compensatedSum
+	for _, s := range sums {
+		total = total.add(s.sum).add(s.compensation)
+	}
+	return total.sum + total.compensation
+}
+
+// compensatedSum holds a running sum and the compensation for the low-order
+// bits lost in computing it.
+type compensatedSum struct {
+	sum          float64
+	compensation float64
+}
+
+// add returns the compensated sum with x added using Neumaier's algorithm.
+func (s compensatedSum) add(x float64) compensatedSum {
+	t := s.sum + x
+	if math.Abs(s.sum) >= math.Abs(x) {
+		s.compensation += (s.sum - t) + x
+	} else {
+		s.compensation += (x - t) + s.sum
+	}
+	s.sum = t
+	return s
+}
+
+// Product returns the product of the values, or one if values is empty.
+//
+// Each partition multiplies its values in order, and the partition products
+// are then multiplied in the order of the partitions, so for a given number
+// of partitions the result is deterministic, even for floating-point numbers.
+func Product[N Number](values []N) N {
+	if len(values) == 0 {
+		return 1
+	}
+
+	partitions, partitionSize := parts(values)
+	products := make([]N, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		product := N(1)
+		for _, v := range values[start:end] {
+			product *= v
+		}
+		products[p] = product
+	})
+
+	total := N(1)
+	for _, v := range products {
+		total *= v
+	}
+	return total
+}
+
+// Min returns the smallest of the values.
+//
+// Each partition finds its smallest value, and the partition results are then
+// compared, without calling a function per value.
+//
+// Panics if values is an empty slice.
+func Min[T Ordered](values []T) T {
+	if len(values) < 1 {
+		panic("cannot find the minimum of an empty slice")
+	}
+
+	partitions, partitionSize := parts(values)
+	results := make([]T, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		m := values[start]
+		for _, v := range values[start+1 : end] {
+			if v < m {
+				m = v
+			}
+		}
+		results[p] = m
+	})
+
+	m := results[0]
+	for _, v := range results[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+// Max returns the largest of the values.
+//
+// Each partition finds its largest value, and the partition results are then
+// compared, without calling a function per value.
+//
+// Panics if values is an empty slice.
+func Max[T Ordered](values []T) T {
+	if len(values) < 1 {
+		panic("cannot find the maximum of an empty slice")
+	}
+
+	partitions, partitionSize := parts(values)
+	results := make([]T, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		m := values[start]
+		for _, v := range values[start+1 : end] {
+			if v > m {
+				m = v
+			}
+		}
+		results[p] = m
+	})
+
+	m := results[0]
+	for _, v := range results[1:] {
+		if v > m {
+			m = v
+		}
+	}
+	return m
+}
+
+// MinBy returns the value for which the key function returns the smallest
+// key. Ties are broken by the order of the original values, so the first of
+// the values with the smallest key is returned.
+//
+// The key function is called exactly once per value.
+//
+// Panics if values is an empty slice.
+func MinBy[T any, K Ordered](values []T, key func(T) K) T {
+	if len(values) < 1 {
+		panic("cannot find the minimum of an empty slice")
+	}
+	return extremeBy(values, key, func(a, b K) bool { return a < b })
+}
+
+// MaxBy returns the value for which the key function returns the largest
+// key. Ties are broken by the order of the original values, so the first of
+// the values with the largest key is returned.
+//
+// The key function is called exactly once per value.
+//
+// Panics if values is an empty slice.
+func MaxBy[T any, K Ordered](values []T, key func(T) K) T {
+	if len(values) < 1 {
+		panic("cannot find the maximum of an empty slice")
+	}
+	return extremeBy(values, key, func(a, b K) bool { return a > b })
+}
+
+// extremeBy returns the first of the values whose key is not preceded by the
+// key of any other value, as defined by before.
+func extremeBy[T any, K Ordered](values []T, key func(T) K, before func(a, b K) bool) T {
+	partitions, partitionSize := parts(values)
+	results := make([]indexed[K], partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		best := indexed[K]{key(values[start]), start}
+		for i := start + 1; i < end; i++ {
+			if k := key(values[i]); before(k, best.value) {
+				best = indexed[K]{k, i}
+			}
+		}
+		results[p] = best
+	})
+
+	best := results[0]
+	for _, r := range results[1:] {
+		if before(r.value, best.value) {
+			best = r
+		}
+	}
+	return values[best.index]
+}
+
+// MaxSubarraySum returns the largest sum of a non-empty run of consecutive
+// values.
+//
+// Each partition is summarized by its total sum and the largest sums of its
+// prefixes, its suffixes and its runs, and the summaries of adjacent
+// partitions are then merged in parallel, as the summary of two adjacent
+// ranges can be computed from their summaries alone.
+//
+// Panics if values is an empty slice.
+func MaxSubarraySum[N Number](values []N) N {
+	if len(values) < 1 {
+		panic("cannot find the maximum subarray of an empty slice")
+	}
+
+	type summary struct {
+		sum, prefix, suffix, best N
+	}
+	partitions, partitionSize := parts(values)
+	summaries := make([]summary, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		v := values[start]
+		s := summary{v, v, v, v}
+		current := v
+		for _, v := range values[start+1 : end] {
+			s.sum += v
+			if s.sum > s.prefix {
+				s.prefix = s.sum
+			}
+			if current < 0 {
+				current = v
+			} else {
+				current += v
+			}
+			if current > s.best {
+				s.best = current
+			}
+		}
+		s.suffix = current
+		summaries[p] = s
+	})
+
+	s := mergeTree(summaries, func(a, b summary) summary {
+		best := a.suffix + b.prefix
+		if a.best > best {
+			best = a.best
+		}
+		if b.best > best {
+			best = b.best
+		}
+		prefix := a.sum + b.prefix
+		if a.prefix > prefix {
+			prefix = a.prefix
+		}
+		suffix := b.sum + a.suffix
+		if b.suffix > suffix {
+			suffix = b.suffix
+		}
+		return summary{a.sum + b.sum, prefix, suffix, best}
+	})
+	return s.best
+}
@@ -0,0 +1,305 @@
+package par_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/jussi-kalliokoski/par"
+)
+
+func TestScatterAdd(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				dst := []int{1, 2, 3, 4, 5, 6, 7}
+				expected := append([]int(nil), dst...)
+				indices := make([]int, l)
+				src := make([]int, l)
+				for i := range src {
+					indices[i] = (i * 3) % len(dst)
+					src[i] = i
+					expected[indices[i]] += src[i]
+				}
+
+				par.ScatterAdd(dst, indices, src)
+
+				assertSliceEquals(t, expected, dst)
+			})
+		}
+	})
+
+	t.Run("different lengths", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.ScatterAdd([]int{0}, []int{0}, []int{1, 2})
+		})
+	})
+
+	t.Run("out of bounds", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.ScatterAdd([]int{0}, []int{0, 1}, []int{1, 2})
+		})
+	})
+}
+
+func TestScore(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				rows := make([][]float64, l)
+				expected := make([]float64, l)
+				for i := range rows {
+					rows[i] = []float64{float64(i), 2}
+					expected[i] = float64(i) * 2
+				}
+				model := func(row []float64) float64 {
+					return row[0] * row[1]
+				}
+
+				assertSliceEquals(t, expected, par.Score(rows, model))
+				assertSliceEquals(t, expected, par.ScoreBatch(rows, func(batch [][]float64) []float64 {
+					scores := make([]float64, len(batch))
+					for i, row := range batch {
+						scores[i] = model(row)
+					}
+					return scores
+				}))
+			})
+		}
+	})
+
+	t.Run("batch mismatch", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.ScoreBatch([][]float64{{1}, {2}}, func(batch [][]float64) []float64 {
+				return nil
+			})
+		})
+	})
+}
+
+func TestSumBy(t *testing.T) {
+	type item struct {
+		Price float64
+		Count int
+	}
+
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]item, l)
+				var expected int
+				for i := range values {
+					values[i] = item{Price: float64(i), Count: i % 5}
+					expected += values[i].Count
+				}
+
+				received := par.SumBy(values, func(v item) int {
+					return v.Count
+				})
+
+				assertEquals(t, expected, received)
+			})
+		}
+	})
+}
+
+func TestSum(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				var expected int
+				for i := range values {
+					values[i] = i%7 - 3
+					expected += values[i]
+				}
+
+				assertEquals(t, expected, par.Sum(values))
+			})
+		}
+	})
+}
+
+func TestSumFloat(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]float64, l)
+				var expected float64
+				for i := range values {
+					switch i % 4 {
+					case 0, 2:
+						values[i] = 1
+						expected++
+					case 1:
+						values[i] = 1e100
+					case 3:
+						values[i] = -1e100
+					}
+				}
+				if l%4 == 2 || l%4 == 3 {
+					expected = 1e100
+				}
+
+				assertEquals(t, expected, par.SumFloat(values))
+			})
+		}
+	})
+}
+
+func TestProduct(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]uint64, l)
+				expected := uint64(1)
+				for i := range values {
+					values[i] = uint64(i%3 + 1)
+					expected *= values[i]
+				}
+
+				assertEquals(t, expected, par.Product(values))
+			})
+		}
+	})
+}
+
+func TestMin(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		t.Run("len 0", func(t *testing.T) {
+			assertPanics(t, func() {
+				par.Min([]int(nil))
+			})
+		})
+
+		for _, l := range testLengths(1) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				expected := 1 << 30
+				for i := range values {
+					values[i] = (i*7919)%1009 - 500
+					expected = minInt(expected, values[i])
+				}
+
+				assertEquals(t, expected, par.Min(values))
+			})
+		}
+	})
+}
+
+func TestMax(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		t.Run("len 0", func(t *testing.T) {
+			assertPanics(t, func() {
+				par.Max([]int(nil))
+			})
+		})
+
+		for _, l := range testLengths(1) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				expected := -1 << 30
+				for i := range values {
+					values[i] = (i*7919)%1009 - 500
+					expected = maxInt(expected, values[i])
+				}
+
+				assertEquals(t, expected, par.Max(values))
+			})
+		}
+	})
+}
+
+func TestMinBy(t *testing.T) {
+	type item struct {
+		Score int
+		ID    int
+	}
+
+	t.Run("lengths", func(t *testing.T) {
+		t.Run("len 0", func(t *testing.T) {
+			assertPanics(t, func() {
+				par.MinBy([]item(nil), func(v item) int { return v.Score })
+			})
+		})
+
+		for _, l := range testLengths(1) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]item, l)
+				expected := item{Score: 1 << 30}
+				for i := range values {
+					values[i] = item{Score: (i * 7919) % 101, ID: i}
+					if values[i].Score < expected.Score {
+						expected = values[i]
+					}
+				}
+
+				assertEquals(t, expected, par.MinBy(values, func(v item) int {
+					return v.Score
+				}))
+			})
+		}
+	})
+}
+
+func TestMaxBy(t *testing.T) {
+	type item struct {
+		Score int
+		ID    int
+	}
+
+	t.Run("lengths", func(t *testing.T) {
+		t.Run("len 0", func(t *testing.T) {
+			assertPanics(t, func() {
+				par.MaxBy([]item(nil), func(v item) int { return v.Score })
+			})
+		})
+
+		for _, l := range testLengths(1) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]item, l)
+				expected := item{Score: -1}
+				for i := range values {
+					values[i] = item{Score: (i * 7919) % 101, ID: i}
+					if values[i].Score > expected.Score {
+						expected = values[i]
+					}
+				}
+
+				assertEquals(t, expected, par.MaxBy(values, func(v item) int {
+					return v.Score
+				}))
+			})
+		}
+	})
+}
+
+func TestMaxSubarraySum(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		t.Run("len 0", func(t *testing.T) {
+			assertPanics(t, func() {
+				par.MaxSubarraySum([]int(nil))
+			})
+		})
+
+		for _, l := range testLengths(1) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				rng := rand.New(rand.NewSource(int64(l)))
+				values := make([]int, l)
+				for i := range values {
+					values[i] = rng.Intn(21) - 12
+				}
+				expected := values[0]
+				for i := range values {
+					var sum int
+					for j := i; j < l; j++ {
+						sum += values[j]
+						expected = maxInt(expected, sum)
+					}
+				}
+
+				assertEquals(t, expected, par.MaxSubarraySum(values))
+			})
+		}
+	})
+}
@@ -67,54 +67,66 @@ func Filter[T any](values []T, predicate func(T) bool) []T {
 		return []T(nil)
 	}
 
-	partitions, partitionSize := parts(values)
+	jobs, totalCount := filterMark(len(values), func(start, end int, bitmap []uint64) int {
+		var count int
+		for i := start; i < end; i++ {
+			if predicate(values[i]) {
+				pos := i - start
+				bitmap[pos/64] |= 1 << (pos % 64)
+				count++
+			}
+		}
+		return count
+	})
+
+	return filterCopy(jobs, totalCount, values)
+}
+
+// filterJob holds the state of a partition in a bitmap-based filter.
+type filterJob struct {
+	bitmap []uint64
+	start  int
+	end    int
+	offset int
+	count  int
+}
+
+// filterMark maps n values into per-partition bitmaps in parallel using the
+// mark function, which sets the bits of the matching values within the given
+// bounds in the bitmap of the partition and returns the number of matches.
+// Returns the jobs, with the offset of each partition in the results, and the
+// total number of matches.
+func filterMark(n int, mark func(start, end int, bitmap []uint64) int) ([]filterJob, int) {
+	partitions, partitionSize := partsOf(n)
 	bitmapSize := partitionSize/64 + 1
-	lastBitmapSize := (len(values)-(partitions-1)*partitionSize)/64 + 1
+	lastBitmapSize := (n-(partitions-1)*partitionSize)/64 + 1
 	fullBitmap := make([]uint64, bitmapSize*(partitions-1)+lastBitmapSize)
-	jobs := make([]struct {
-		bitmap []uint64
-		start  int
-		end    int
-		offset int
-		count  int
-	}, partitions)
+	jobs := make([]filterJob, partitions)
 
-	var wg sync.WaitGroup
-	wg.Add(partitions)
-	for p := range jobs {
+	forEachPart(partitions, partitionSize, n, func(p, start, end int) {
 		jobs[p].bitmap = fullBitmap[bitmapSize*p:]
-		jobs[p].start = p * partitionSize
-		jobs[p].end = jobs[p].start + partitionSize
-		if p == partitions-1 {
-			jobs[p].end = len(values)
-		}
-		go func(p int) {
-			defer wg.Done()
-			j := jobs[p]
-			for i := j.start; i < j.end; i++ {
-				if predicate(values[i]) {
-					pos := i - j.start
-					j.bitmap[pos/64] |= 1 << (pos % 64)
-					j.count++
-				}
-			}
-			jobs[p].count = j.count
-		}(p)
-	}
-	wg.Wait()
+		jobs[p].start = start
+		jobs[p].end = end
+		jobs[p].count = mark(start, end, jobs[p].bitmap)
+	})
 
 	var totalCount int
 	for p := range jobs {
 		jobs[p].offset = totalCount
 		totalCount += jobs[p].count
 	}
+	return jobs, totalCount
+}
 
+// filterCopy copies the values marked in the bitmaps of the jobs into a
+// results slice in parallel.
+func filterCopy[T any](jobs []filterJob, totalCount int, values []T) []T {
 	result := make([]T, totalCount)
-	wg.Add(partitions)
+	var wg sync.WaitGroup
+	wg.Add(len(jobs))
 	for p := range jobs {
-		go func(p int) {
+		go func(j filterJob) {
 			defer wg.Done()
-			j := jobs[p]
 			for i := j.start; i < j.end; i++ {
 				pos := i - j.start
 				if (j.bitmap[pos/64] & (1 << (pos % 64))) > 0 {
@@ -122,7 +134,30 @@ func Filter[T any](values []T, predicate func(T) bool) []T {
 					j.offset++
 				}
 			}
-		}(p)
+		}(jobs[p])
+	}
+	wg.Wait()
+
+	return result
+}
+
+// filterIndices returns the indices marked in the bitmaps of the jobs,
+// computed in parallel.
+func filterIndices(jobs []filterJob, totalCount int) []int {
+	result := make([]int, totalCount)
+	var wg sync.WaitGroup
+	wg.Add(len(jobs))
+	for p := range jobs {
+		go func(j filterJob) {
+			defer wg.Done()
+			for i := j.start; i < j.end; i++ {
+				pos := i - j.start
+				if (j.bitmap[pos/64] & (1 << (pos % 64))) > 0 {
+					result[j.offset] = i
+					j.offset++
+				}
+			}
+		}(jobs[p])
 	}
 	wg.Wait()
 
@@ -138,12 +173,27 @@ func Filter[T any](values []T, predicate func(T) bool) []T {
 // - The current element being processed.
 // The accumulator then returns the result of combining these two values.
 //
+// The ordering of the accumulations is deterministic, as with ReduceOrdered,
+// so the accumulator needs to be associative, but not commutative. Use
+// ReduceUnordered for accumulators that are also commutative.
+//
+// Panics if values is an empty slice.
+func Reduce[T any](values []T, accumulator func(T, T) T) T {
+	return ReduceOrdered(values, accumulator)
+}
+
+// ReduceUnordered reduces the values to a single value by repeatedly applying
+// an accumulator, without a fixed combination order for the results of the
+// partitions.
+//
 // The ordering of the accumulations is deterministic and linear only within a
 // partition. The implementation works by reducing each partition into a single
-// value and then reducing the values from each partition as they become ready.
+// value and then reducing the values from each partition as they become ready,
+// without waiting for the earlier partitions. The accumulator thus needs to be
+// both associative and commutative.
 //
 // Panics if values is an empty slice.
-func Reduce[T any](values []T, accumulator func(T, T) T) T {
+func ReduceUnordered[T any](values []T, accumulator func(T, T) T) T {
 	if len(values) < 1 {
 		panic("cannot reduce an empty slice")
 	}
@@ -172,6 +222,37 @@ func Reduce[T any](values []T, accumulator func(T, T) T) T {
 	return v
 }
 
+// ReduceOrdered reduces the values to a single value by repeatedly applying
+// an accumulator, combining the results of the partitions in a fixed order.
+// This is the default behavior of Reduce, and ReduceOrdered is its explicit
+// name for code that relies on the ordering.
+//
+// Each partition reduces its values in order, then the results of the
+// partitions are combined in a fixed binary tree, always combining adjacent
+// partitions with the result of the earlier partition as the first argument.
+// The accumulator thus needs to be associative, but not commutative, and for
+// a given number of partitions the result is bit-identical across runs, even
+// for floating-point numbers.
+//
+// Panics if values is an empty slice.
+func ReduceOrdered[T any](values []T, accumulator func(T, T) T) T {
+	if len(values) < 1 {
+		panic("cannot reduce an empty slice")
+	}
+
+	partitions, partitionSize := parts(values)
+	results := make([]T, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		v := values[start]
+		for i := start + 1; i < end; i++ {
+			v = accumulator(v, values[i])
+		}
+		results[p] = v
+	})
+
+	return mergeTree(results, accumulator)
+}
+
 // Any returns a boolean indicating if predicate returns true for any of the
 // values.
 //
@@ -245,8 +326,74 @@ func None[T any](values []T, predicate func(T) bool) bool {
 // parts returns the number of partitions and the size optimised for
 // the available CPUs and given values.
 func parts[In any](values []In) (count, size int) {
-	if p := runtime.GOMAXPROCS(0); p <= len(values) {
-		return p, len(values) / p
+	return partsOf(len(values))
+}
+
+// partsOf returns the number of partitions and the size optimised for the
+// available CPUs and given number of values.
+func partsOf(n int) (count, size int) {
+	if p := runtime.GOMAXPROCS(0); p <= n {
+		return p, n / p
+	}
+	return n, 1
+}
+
+// forEachPart calls fn in parallel for each of the partitions of n values
+// with the index and bounds of the partition, and waits for all of the calls
+// to return.
+func forEachPart(partitions, partitionSize, n int, fn func(p, start, end int)) {
+	var wg sync.WaitGroup
+	wg.Add(partitions)
+	for p := 0; p < partitions; p++ {
+		start := partitionSize * p
+		end := start + partitionSize
+		if p == partitions-1 {
+			end = n
+		}
+		go func(p, start, end int) {
+			defer wg.Done()
+			fn(p, start, end)
+		}(p, start, end)
+	}
+	wg.Wait()
+}
+
+// minInt returns the smaller of a and b.
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// mergeTree merges the results into a single value using a parallel binary
+// tree of merges. Only adjacent results are merged, so the order of the
+// results is maintained.
+func mergeTree[R any](results []R, merge func(R, R) R) R {
+	for len(results) > 1 {
+		pairs := len(results) / 2
+		next := make([]R, pairs, pairs+1)
+		var wg sync.WaitGroup
+		wg.Add(pairs)
+		for i := 0; i < pairs; i++ {
+			go func(i int) {
+				defer wg.Done()
+				next[i] = merge(results[2*i], results[2*i+1])
+			}(i)
+		}
+		wg.Wait()
+		if len(results)%2 == 1 {
+			next = append(next, results[len(results)-1])
+		}
+		results = next
+	}
+	return results[0]
+}
+
+// maxInt returns the larger of a and b.
+func maxInt(a, b int) int {
+	if a > b {
+		return a
 	}
-	return len(values), 1
+	return b
 }
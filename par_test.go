@@ -1,7 +1,9 @@
 package par_test
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"math/rand"
 	"testing"
 
@@ -109,6 +111,64 @@ func TestReduce(t *testing.T) {
 	})
 }
 
+func TestReduceUnordered(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		t.Run("len 0", func(t *testing.T) {
+			assertPanics(t, func() {
+				par.ReduceUnordered([]int(nil), func(a, b int) int {
+					return a + b
+				})
+			})
+		})
+
+		for _, l := range testLengths(1) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				var expected int
+				for i := range values {
+					values[i] = i
+					expected += i
+				}
+
+				received := par.ReduceUnordered(values, func(a, b int) int {
+					return a + b
+				})
+
+				assertEquals(t, expected, received)
+			})
+		}
+	})
+}
+
+func TestReduceOrdered(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		t.Run("len 0", func(t *testing.T) {
+			assertPanics(t, func() {
+				par.ReduceOrdered([]string(nil), func(a, b string) string {
+					return a + b
+				})
+			})
+		})
+
+		for _, l := range testLengths(1) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]string, l)
+				var expected string
+				for i := range values {
+					values[i] = string(rune('a' + i%26))
+					expected += values[i]
+				}
+
+				received := par.ReduceOrdered(values, func(a, b string) string {
+					return a + b
+				})
+
+				assertEquals(t, expected, received)
+			})
+		}
+	})
+}
+
 func TestAny(t *testing.T) {
 	t.Run("lengths", func(t *testing.T) {
 		t.Run("len 0", func(t *testing.T) {
@@ -538,6 +598,32 @@ func (c Collection) JoinSums(c2 Collection) Collection {
 	return Collection{numbers}
 }
 
+// testLengths returns the slice lengths to test with, starting from min.
+func testLengths(min int) []int {
+	tests := []int(nil)
+	for i := min; i < 128; i++ {
+		tests = append(tests, i)
+	}
+	for i := 128; i < 2048; i = i << 1 {
+		tests = append(tests, i)
+	}
+	return tests
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
 func assertSliceEquals[T comparable](tb testing.TB, expected, received []T) {
 	tb.Helper()
 	if len(expected) != len(received) {
@@ -557,6 +643,27 @@ func assertEquals[T comparable](tb testing.TB, expected, received T) {
 	}
 }
 
+func assertApprox(tb testing.TB, expected, received, tolerance float64) {
+	tb.Helper()
+	if math.Abs(expected-received) > tolerance {
+		tb.Fatalf("expected `%v` within %v, got `%v`", expected, tolerance, received)
+	}
+}
+
+func assertNoError(tb testing.TB, err error) {
+	tb.Helper()
+	if err != nil {
+		tb.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func assertError(tb testing.TB, expected, received error) {
+	tb.Helper()
+	if !errors.Is(received, expected) {
+		tb.Fatalf("expected error `%v`, got `%v`", expected, received)
+	}
+}
+
 func assertPanics(tb testing.TB, fn func()) {
 	tb.Helper()
 	defer func() {
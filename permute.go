@@ -0,0 +1,89 @@
+package par
+
+import (
+	"sync/atomic"
+)
+
+// Gather returns a slice where each item is the item in values at the index
+// given by the item at the same index in indices, i.e.
+// result[i] = values[indices[i]].
+//
+// The implementation is deterministic, and the returned slice maintains the
+// order of indices.
+//
+// Panics if any of the indices is out of the bounds of values.
+func Gather[T any](values []T, indices []int) []T {
+	if len(indices) == 0 {
+		return []T(nil)
+	}
+
+	partitions, partitionSize := parts(indices)
+	result := make([]T, len(indices))
+	outOfBounds := make([]bool, partitions)
+	forEachPart(partitions, partitionSize, len(indices), func(p, start, end int) {
+		for i := start; i < end; i++ {
+			if uint(indices[i]) >= uint(len(values)) {
+				outOfBounds[p] = true
+				return
+			}
+			result[i] = values[indices[i]]
+		}
+	})
+	for _, o := range outOfBounds {
+		if o {
+			panic("gather index out of bounds")
+		}
+	}
+
+	return result
+}
+
+// Scatter writes each item in values into dst at the index given by the item
+// at the same index in indices, i.e. dst[indices[i]] = values[i].
+//
+// The indices must be distinct, as the items are written in parallel:
+// duplicate indices result in concurrent writes to the same item of dst.
+//
+// Panics if indices and values are of different lengths, or if any of the
+// indices is out of the bounds of dst.
+func Scatter[T any](dst []T, indices []int, values []T) {
+	if len(indices) != len(values) {
+		panic("cannot scatter values with indices of a different length")
+	}
+	if Any(indices, func(idx int) bool { return uint(idx) >= uint(len(dst)) }) {
+		panic("scatter index out of bounds")
+	}
+	if len(values) == 0 {
+		return
+	}
+
+	partitions, partitionSize := parts(values)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		for i := start; i < end; i++ {
+			dst[indices[i]] = values[i]
+		}
+	})
+}
+
+// ApplyPermutation returns a copy of values reordered by the permutation
+// perm, so that result[i] = values[perm[i]]. For example, applying the
+// indices that sort values results in a sorted copy of values.
+//
+// The permutation is validated in parallel, then the result is gathered in
+// parallel.
+//
+// Panics if perm is not a permutation of the indices of values, i.e. if it is
+// of a different length than values, or contains an index that is out of
+// bounds or appears more than once.
+func ApplyPermutation[T any](values []T, perm []int) []T {
+	if len(perm) != len(values) {
+		panic("cannot apply a permutation of a different length")
+	}
+	seen := make([]uint32, len(perm))
+	if Any(perm, func(idx int) bool {
+		return uint(idx) >= uint(len(seen)) || atomic.AddUint32(&seen[idx], 1) != 1
+	}) {
+		panic("invalid permutation")
+	}
+	return Gather(values, perm)
+}
@@ -0,0 +1,109 @@
+package par_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jussi-kalliokoski/par"
+)
+
+func TestGather(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := []string{"a", "b", "c", "d", "e"}
+				indices := make([]int, l)
+				expected := make([]string, l)
+				for i := range indices {
+					indices[i] = (i * 3) % len(values)
+					expected[i] = values[indices[i]]
+				}
+
+				received := par.Gather(values, indices)
+
+				assertSliceEquals(t, expected, received)
+			})
+		}
+	})
+
+	t.Run("out of bounds", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.Gather([]int{1, 2}, []int{0, 2})
+		})
+	})
+}
+
+func TestScatter(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				indices := make([]int, l)
+				expected := make([]int, l)
+				for i := range values {
+					values[i] = i
+					indices[i] = l - 1 - i
+					expected[l-1-i] = i
+				}
+
+				dst := make([]int, l)
+				par.Scatter(dst, indices, values)
+
+				assertSliceEquals(t, expected, dst)
+			})
+		}
+	})
+
+	t.Run("different lengths", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.Scatter(make([]int, 2), []int{0, 1}, []int{1})
+		})
+	})
+
+	t.Run("out of bounds", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.Scatter(make([]int, 2), []int{0, 2}, []int{1, 2})
+		})
+	})
+}
+
+func TestApplyPermutation(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				perm := make([]int, l)
+				expected := make([]int, l)
+				for i := range values {
+					values[i] = i * 10
+					perm[i] = (i + 7) % l
+				}
+				for i := range expected {
+					expected[i] = values[perm[i]]
+				}
+
+				received := par.ApplyPermutation(values, perm)
+
+				assertSliceEquals(t, expected, received)
+			})
+		}
+	})
+
+	t.Run("different lengths", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.ApplyPermutation([]int{1, 2}, []int{0})
+		})
+	})
+
+	t.Run("duplicate index", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.ApplyPermutation([]int{1, 2, 3}, []int{0, 1, 1})
+		})
+	})
+
+	t.Run("out of bounds", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.ApplyPermutation([]int{1, 2}, []int{0, 2})
+		})
+	})
+}
@@ -0,0 +1,77 @@
+package par
+
+// SortInts sorts the values in ascending order in place using a parallel
+// radix sort, which for large slices is considerably faster than a
+// comparison sort.
+//
+// The implementation is the same as with SortByUintKey.
+func SortInts(values []int) {
+	SortByUintKey(values, func(v int) uint64 {
+		return uint64(v) ^ (1 << 63)
+	})
+}
+
+// SortByUintKey sorts the values in place in ascending order of the keys
+// returned by the key function using a parallel radix sort, keeping the
+// original order of the values with equal keys.
+//
+// The keys are extracted in a parallel pass, so the key function is called
+// exactly once per value. The values are then sorted with a least
+// significant digit radix sort, one byte of the keys at a time: the digits of
+// each partition are counted in parallel, the offsets of each digit in each
+// partition are computed from the counts, and the values are then placed at
+// their offsets in parallel. Bytes that are equal for all of the keys are
+// skipped.
+func SortByUintKey[T any](values []T, key func(T) uint64) {
+	if len(values) < 2 {
+		return
+	}
+
+	n := len(values)
+	partitions, partitionSize := parts(values)
+	srcValues, dstValues := values, make([]T, n)
+	srcKeys, dstKeys := Map(values, key), make([]uint64, n)
+	offsets := make([]int, partitions*256)
+	for shift := uint(0); shift < 64; shift += 8 {
+		forEachPart(partitions, partitionSize, n, func(p, start, end int) {
+			counts := offsets[p*256 : (p+1)*256]
+			for d := range counts {
+				counts[d] = 0
+			}
+			for _, k := range srcKeys[start:end] {
+				counts[(k>>shift)&0xff]++
+			}
+		})
+
+		var total int
+		skip := false
+		for d := 0; d < 256; d++ {
+			digitStart := total
+			for p := 0; p < partitions; p++ {
+				offsets[p*256+d], total = total, total+offsets[p*256+d]
+			}
+			if total-digitStart == n {
+				skip = true
+			}
+		}
+		if skip {
+			continue
+		}
+
+		forEachPart(partitions, partitionSize, n, func(p, start, end int) {
+			o := offsets[p*256 : (p+1)*256]
+			for i := start; i < end; i++ {
+				d := (srcKeys[i] >> shift) & 0xff
+				dstValues[o[d]] = srcValues[i]
+				dstKeys[o[d]] = srcKeys[i]
+				o[d]++
+			}
+		})
+		srcValues, dstValues = dstValues, srcValues
+		srcKeys, dstKeys = dstKeys, srcKeys
+	}
+
+	if &srcValues[0] != &values[0] {
+		copy(values, srcValues)
+	}
+}
@@ -0,0 +1,84 @@
+package par_test
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/jussi-kalliokoski/par"
+)
+
+func TestSortInts(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range append(testLengths(0), 100000) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				rng := rand.New(rand.NewSource(int64(l)))
+				values := make([]int, l)
+				for i := range values {
+					values[i] = rng.Int() - rng.Int()
+					if i%3 == 0 {
+						values[i] = rng.Intn(100) - 50
+					}
+				}
+				expected := append([]int(nil), values...)
+				sort.Ints(expected)
+
+				par.SortInts(values)
+
+				assertSliceEquals(t, expected, values)
+			})
+		}
+	})
+}
+
+func TestSortByUintKey(t *testing.T) {
+	type record struct {
+		Key   uint64
+		Index int
+	}
+
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				rng := rand.New(rand.NewSource(int64(l)))
+				values := make([]record, l)
+				for i := range values {
+					values[i] = record{uint64(rng.Intn(300)) << 20, i}
+				}
+				expected := append([]record(nil), values...)
+				sort.SliceStable(expected, func(i, j int) bool {
+					return expected[i].Key < expected[j].Key
+				})
+
+				par.SortByUintKey(values, func(v record) uint64 {
+					return v.Key
+				})
+
+				assertSliceEquals(t, expected, values)
+			})
+		}
+	})
+}
+
+func BenchmarkSortInts(b *testing.B) {
+	rand.Seed(1)
+	values := make([]int, 10000000)
+	for i := range values {
+		values[i] = rand.Int()
+	}
+	buf := make([]int, len(values))
+
+	b.Run("comparison", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			copy(buf, values)
+			par.Sort(buf)
+		}
+	})
+	b.Run("radix", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			copy(buf, values)
+			par.SortInts(buf)
+		}
+	})
+}
@@ -0,0 +1,412 @@
+package par
+
+import (
+	"sort"
+)
+
+// Expand returns a slice where each item in values is repeated the number of
+// times given by the item at the same index in counts.
+//
+// The implementation is deterministic, and the returned slice maintains the
+// order of the original values.
+//
+// Internally, the output offsets are computed with a parallel prefix sum:
+// the counts of each partition are summed in parallel, then the partition
+// totals are summed serially to get the offset of each partition, then the
+// repeats are written into the results slice in parallel.
+//
+// Panics if values and counts are of different lengths, or if any of the
+// counts is negative.
+func Expand[T any](values []T, counts []int) []T {
+	if len(values) != len(counts) {
+		panic("cannot expand values with counts of a different length")
+	}
+	if len(values) == 0 {
+		return []T(nil)
+	}
+
+	partitions, partitionSize := parts(values)
+	offsets := make([]int, partitions)
+	negative := make([]bool, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		var total int
+		for _, c := range counts[start:end] {
+			if c < 0 {
+				negative[p] = true
+			}
+			total += c
+		}
+		offsets[p] = total
+	})
+
+	var total int
+	for p := range offsets {
+		if negative[p] {
+			panic("cannot expand with a negative count")
+		}
+		offsets[p], total = total, total+offsets[p]
+	}
+
+	result := make([]T, total)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		offset := offsets[p]
+		for i := start; i < end; i++ {
+			next := offset + counts[i]
+			for j := offset; j < next; j++ {
+				result[j] = values[i]
+			}
+			offset = next
+		}
+	})
+
+	return result
+}
+
+// Interleave returns a slice that contains the items of the given slices
+// interleaved in a round-robin fashion, i.e. the first item of each slice,
+// followed by the second item of each slice, and so on.
+//
+// The position of each item in the result is computed arithmetically, so each
+// partition writes its own region of the result without coordination.
+//
+// Panics if the slices are of different lengths.
+func Interleave[T any](slices ...[]T) []T {
+	if len(slices) == 0 {
+		return []T(nil)
+	}
+	for _, s := range slices[1:] {
+		if len(s) != len(slices[0]) {
+			panic("cannot interleave slices of different lengths")
+		}
+	}
+	if len(slices[0]) == 0 {
+		return []T(nil)
+	}
+
+	n := len(slices) * len(slices[0])
+	partitions, partitionSize := partsOf(n)
+	result := make([]T, n)
+	forEachPart(partitions, partitionSize, n, func(p, start, end int) {
+		for i := start; i < end; i++ {
+			result[i] = slices[i%len(slices)][i/len(slices)]
+		}
+	})
+
+	return result
+}
+
+// SplitMode defines how Split distributes the values into the slices.
+type SplitMode int
+
+const (
+	// SplitContiguous distributes contiguous ranges of values into the
+	// slices, so that the first slice holds the first values, and so on.
+	SplitContiguous SplitMode = iota
+	// SplitRoundRobin distributes the values into the slices in a round-robin
+	// fashion, so that the first value goes to the first slice, the second
+	// value to the second slice, and so on.
+	SplitRoundRobin
+)
+
+// Split returns n slices that hold copies of the values, distributed as
+// defined by mode. The lengths of the slices differ by at most one, with the
+// first slices being the longer ones.
+//
+// The implementation is deterministic, and each slice maintains the order of
+// the original values. The returned slices share a single allocation, but do
+// not share capacity, so appending to one does not overwrite another.
+//
+// Panics if n is less than 1.
+func Split[T any](values []T, n int, mode SplitMode) [][]T {
+	if n < 1 {
+		panic("cannot split into less than one slice")
+	}
+
+	buf := make([]T, len(values))
+	result := make([][]T, n)
+	q, r := len(values)/n, len(values)%n
+	for s := range result {
+		start := s*q + minInt(s, r)
+		end := start + q
+		if s < r {
+			end++
+		}
+		result[s] = buf[start:end:end]
+	}
+	if len(values) == 0 {
+		return result
+	}
+
+	partitions, partitionSize := parts(values)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		if mode == SplitContiguous {
+			copy(buf[start:end], values[start:end])
+			return
+		}
+		for i := start; i < end; i++ {
+			result[i%n][i/n] = values[i]
+		}
+	})
+
+	return result
+}
+
+// Chunk returns views of consecutive chunks of values, each of the given
+// size, except for the last chunk which holds the remaining values and may
+// be shorter.
+//
+// The chunks are views into values and not copies, but they do not share
+// capacity, so appending to one chunk does not overwrite the next one.
+//
+// Panics if size is less than 1.
+func Chunk[T any](values []T, size int) [][]T {
+	if size < 1 {
+		panic("cannot chunk with a size less than one")
+	}
+	if len(values) == 0 {
+		return [][]T(nil)
+	}
+
+	result := make([][]T, (len(values)+size-1)/size)
+	for c := range result {
+		start := c * size
+		end := minInt(start+size, len(values))
+		result[c] = values[start:end:end]
+	}
+	return result
+}
+
+// Concat returns the given slices concatenated into a single slice.
+//
+// The offsets of the slices in the result are computed serially, then the
+// result is divided into partitions of equal size, and each partition copies
+// the parts of the slices that fall within it in parallel, so the work is
+// divided evenly regardless of the lengths of the individual slices.
+func Concat[T any](slices [][]T) []T {
+	offsets := make([]int, len(slices)+1)
+	for s := range slices {
+		offsets[s+1] = offsets[s] + len(slices[s])
+	}
+	total := offsets[len(slices)]
+	if total == 0 {
+		return []T(nil)
+	}
+
+	partitions, partitionSize := partsOf(total)
+	result := make([]T, total)
+	forEachPart(partitions, partitionSize, total, func(p, start, end int) {
+		s := sort.Search(len(slices), func(s int) bool {
+			return offsets[s+1] > start
+		})
+		for i := start; i < end; s++ {
+			n := copy(result[i:end], slices[s][i-offsets[s]:])
+			i += n
+		}
+	})
+
+	return result
+}
+
+// Deinterleave splits interleaved values into stride slices, so that the
+// first slice holds every stride-th item starting from the first item, the
+// second slice every stride-th item starting from the second item, and so on.
+// It is the inverse of Interleave.
+//
+// The implementation is deterministic, and each slice maintains the order of
+// the original values. The returned slices share a single allocation, but do
+// not share capacity, so appending to one does not overwrite another.
+//
+// Panics if stride is less than 1 or the length of values is not a multiple
+// of stride.
+func Deinterleave[T any](values []T, stride int) [][]T {
+	if stride < 1 {
+		panic("cannot deinterleave with a stride less than one")
+	}
+	if len(values)%stride != 0 {
+		panic("cannot deinterleave values with a length that is not a multiple of stride")
+	}
+
+	n := len(values) / stride
+	buf := make([]T, len(values))
+	result := make([][]T, stride)
+	for s := range result {
+		result[s] = buf[s*n : (s+1)*n : (s+1)*n]
+	}
+	if len(values) == 0 {
+		return result
+	}
+
+	partitions, partitionSize := parts(values)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		for i := start; i < end; i++ {
+			buf[(i%stride)*n+i/stride] = values[i]
+		}
+	})
+
+	return result
+}
+
+// Reshape returns views of values as rows of rowLen items each, e.g. for
+// treating a flat buffer as a row-major matrix. It is the inverse of Flatten.
+//
+// The rows are views into values and not copies, but they do not share
+// capacity, so appending to one row does not overwrite the next one. The row
+// views are built in parallel.
+//
+// Panics if rowLen is less than 1 or the length of values is not a multiple
+// of rowLen.
+func Reshape[T any](values []T, rowLen int) [][]T {
+	if rowLen < 1 {
+		panic("cannot reshape with a row length less than one")
+	}
+	if len(values)%rowLen != 0 {
+		panic("cannot reshape values with a length that is not a multiple of row length")
+	}
+	if len(values) == 0 {
+		return [][]T(nil)
+	}
+
+	rows := len(values) / rowLen
+	partitions, partitionSize := partsOf(rows)
+	result := make([][]T, rows)
+	forEachPart(partitions, partitionSize, rows, func(p, start, end int) {
+		for r := start; r < end; r++ {
+			result[r] = values[r*rowLen : (r+1)*rowLen : (r+1)*rowLen]
+		}
+	})
+
+	return result
+}
+
+// Flatten returns a copy of the rows concatenated into a single slice, e.g.
+// for turning a row-major matrix into a flat buffer. It is the inverse of
+// Reshape.
+//
+// Panics if the rows are of different lengths. Use Concat to concatenate
+// slices of different lengths.
+func Flatten[T any](rows [][]T) []T {
+	for _, row := range rows {
+		if len(row) != len(rows[0]) {
+			panic("cannot flatten rows of different lengths")
+		}
+	}
+	return Concat(rows)
+}
+
+// Repeat returns a slice of length n with every item set to value.
+//
+// The slice is filled in parallel, which benefits large slices as filling
+// memory is bound by memory bandwidth rather than a single core.
+//
+// Panics if n is negative.
+func Repeat[T any](value T, n int) []T {
+	if n < 0 {
+		panic("cannot repeat a negative number of times")
+	}
+	if n == 0 {
+		return []T(nil)
+	}
+
+	partitions, partitionSize := partsOf(n)
+	result := make([]T, n)
+	forEachPart(partitions, partitionSize, n, func(p, start, end int) {
+		for i := start; i < end; i++ {
+			result[i] = value
+		}
+	})
+
+	return result
+}
+
+// Generate returns a slice of length n where each item is the result of
+// calling fn with the index of the item.
+//
+// The implementation is deterministic: fn is called exactly once per index.
+//
+// Panics if n is negative.
+func Generate[T any](n int, fn func(i int) T) []T {
+	if n < 0 {
+		panic("cannot generate a negative number of values")
+	}
+	if n == 0 {
+		return []T(nil)
+	}
+
+	partitions, partitionSize := partsOf(n)
+	result := make([]T, n)
+	forEachPart(partitions, partitionSize, n, func(p, start, end int) {
+		for i := start; i < end; i++ {
+			result[i] = fn(i)
+		}
+	})
+
+	return result
+}
+
+// SplitBy returns n slices that hold copies of the values, distributed by the
+// classify function, which returns the index of the slice each value belongs
+// to.
+//
+// The implementation is deterministic, and each slice maintains the order of
+// the original values. The returned slices share a single allocation, but do
+// not share capacity, so appending to one does not overwrite another.
+//
+// Internally, the classes of the values and their per-partition counts are
+// computed in parallel, then the offsets of each class in each partition are
+// computed from the counts, then the values are copied into the results in
+// parallel.
+//
+// Panics if n is less than 1, or if classify returns an index outside the
+// range [0, n).
+func SplitBy[T any](values []T, classify func(T) int, n int) [][]T {
+	if n < 1 {
+		panic("cannot split into less than one slice")
+	}
+
+	result := make([][]T, n)
+	if len(values) == 0 {
+		return result
+	}
+
+	partitions, partitionSize := parts(values)
+	classes := make([]int, len(values))
+	offsets := make([]int, partitions*n)
+	outOfRange := make([]bool, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		counts := offsets[p*n : (p+1)*n]
+		for i := start; i < end; i++ {
+			c := classify(values[i])
+			if uint(c) >= uint(n) {
+				outOfRange[p] = true
+				return
+			}
+			classes[i] = c
+			counts[c]++
+		}
+	})
+	for _, o := range outOfRange {
+		if o {
+			panic("classify returned an index out of range")
+		}
+	}
+
+	buf := make([]T, len(values))
+	var total int
+	for c := 0; c < n; c++ {
+		start := total
+		for p := 0; p < partitions; p++ {
+			offsets[p*n+c], total = total, total+offsets[p*n+c]
+		}
+		result[c] = buf[start:total:total]
+	}
+
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		o := offsets[p*n : (p+1)*n]
+		for i := start; i < end; i++ {
+			buf[o[classes[i]]] = values[i]
+			o[classes[i]]++
+		}
+	})
+
+	return result
+}
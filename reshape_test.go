@@ -0,0 +1,387 @@
+package par_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jussi-kalliokoski/par"
+)
+
+func TestExpand(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		tests := []int(nil)
+		for i := 0; i < 128; i++ {
+			tests = append(tests, i)
+		}
+		for i := 128; i < 2048; i = i << 1 {
+			tests = append(tests, i)
+		}
+		for _, l := range tests {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				counts := make([]int, l)
+				expected := []int(nil)
+				for i := range values {
+					values[i] = i
+					counts[i] = i % 4
+					for c := 0; c < counts[i]; c++ {
+						expected = append(expected, i)
+					}
+				}
+
+				received := par.Expand(values, counts)
+
+				assertSliceEquals(t, expected, received)
+			})
+		}
+	})
+
+	t.Run("different lengths", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.Expand([]int{1, 2}, []int{1})
+		})
+	})
+
+	t.Run("negative count", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.Expand([]int{1, 2}, []int{1, -1})
+		})
+	})
+}
+
+func TestInterleave(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				a := make([]int, l)
+				b := make([]int, l)
+				c := make([]int, l)
+				expected := []int(nil)
+				for i := 0; i < l; i++ {
+					a[i], b[i], c[i] = i, -i, i*10
+					expected = append(expected, a[i], b[i], c[i])
+				}
+
+				received := par.Interleave(a, b, c)
+
+				assertSliceEquals(t, expected, received)
+			})
+		}
+	})
+
+	t.Run("no slices", func(t *testing.T) {
+		assertEquals(t, 0, len(par.Interleave[int]()))
+	})
+
+	t.Run("different lengths", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.Interleave([]int{1, 2}, []int{1})
+		})
+	})
+}
+
+func TestSplit(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			for _, n := range []int{1, 3, 7} {
+				t.Run(fmt.Sprintf("len %d into %d", l, n), func(t *testing.T) {
+					values := make([]int, l)
+					for i := range values {
+						values[i] = i
+					}
+
+					t.Run("contiguous", func(t *testing.T) {
+						received := par.Split(values, n, par.SplitContiguous)
+
+						assertEquals(t, n, len(received))
+						var next int
+						for s := range received {
+							assertEquals(t, true, len(received[s]) == l/n || len(received[s]) == l/n+1)
+							for _, v := range received[s] {
+								assertEquals(t, next, v)
+								next++
+							}
+						}
+						assertEquals(t, l, next)
+					})
+
+					t.Run("round robin", func(t *testing.T) {
+						received := par.Split(values, n, par.SplitRoundRobin)
+
+						assertEquals(t, n, len(received))
+						expected := make([][]int, n)
+						for i, v := range values {
+							expected[i%n] = append(expected[i%n], v)
+						}
+						for s := range received {
+							assertSliceEquals(t, expected[s], received[s])
+						}
+					})
+				})
+			}
+		}
+	})
+
+	t.Run("invalid n", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.Split([]int{1, 2}, 0, par.SplitContiguous)
+		})
+	})
+}
+
+func TestChunk(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			for _, size := range []int{1, 3, 64} {
+				t.Run(fmt.Sprintf("len %d by %d", l, size), func(t *testing.T) {
+					values := make([]int, l)
+					for i := range values {
+						values[i] = i
+					}
+
+					received := par.Chunk(values, size)
+
+					assertEquals(t, (l+size-1)/size, len(received))
+					for c := range received {
+						end := (c + 1) * size
+						if end > l {
+							end = l
+						}
+						assertSliceEquals(t, values[c*size:end], received[c])
+						assertEquals(t, len(received[c]), cap(received[c]))
+					}
+				})
+			}
+		}
+	})
+
+	t.Run("invalid size", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.Chunk([]int{1, 2}, 0)
+		})
+	})
+}
+
+func TestConcat(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				slices := make([][]int, l)
+				expected := []int(nil)
+				for i := range slices {
+					for j := 0; j < i%5; j++ {
+						slices[i] = append(slices[i], i*10+j)
+						expected = append(expected, i*10+j)
+					}
+				}
+
+				received := par.Concat(slices)
+
+				assertSliceEquals(t, expected, received)
+			})
+		}
+	})
+
+	t.Run("single large slice", func(t *testing.T) {
+		values := make([]int, 1000)
+		for i := range values {
+			values[i] = i
+		}
+
+		received := par.Concat([][]int{nil, values, nil})
+
+		assertSliceEquals(t, values, received)
+	})
+}
+
+func BenchmarkConcat(b *testing.B) {
+	slices := make([][]int, 1000)
+	for i := range slices {
+		slices[i] = make([]int, 10000)
+	}
+
+	b.Run("serial", func(b *testing.B) {
+		var r bool
+		for n := 0; n < b.N; n++ {
+			result := []int(nil)
+			for _, s := range slices {
+				result = append(result, s...)
+			}
+			r = len(result) == 123
+		}
+		deadBool = r
+	})
+	b.Run("parallel", func(b *testing.B) {
+		var r bool
+		for n := 0; n < b.N; n++ {
+			result := par.Concat(slices)
+			r = len(result) == 123
+		}
+		deadBool = r
+	})
+}
+
+func TestDeinterleave(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				a := make([]int, l)
+				b := make([]int, l)
+				c := make([]int, l)
+				for i := 0; i < l; i++ {
+					a[i], b[i], c[i] = i, -i, i*10
+				}
+
+				received := par.Deinterleave(par.Interleave(a, b, c), 3)
+
+				assertEquals(t, 3, len(received))
+				assertSliceEquals(t, a, received[0])
+				assertSliceEquals(t, b, received[1])
+				assertSliceEquals(t, c, received[2])
+			})
+		}
+	})
+
+	t.Run("invalid stride", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.Deinterleave([]int{1, 2}, 0)
+		})
+	})
+
+	t.Run("uneven length", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.Deinterleave([]int{1, 2, 3}, 2)
+		})
+	})
+}
+
+func TestReshape(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			for _, rowLen := range []int{1, 4} {
+				if l%rowLen != 0 {
+					continue
+				}
+				t.Run(fmt.Sprintf("len %d by %d", l, rowLen), func(t *testing.T) {
+					values := make([]int, l)
+					for i := range values {
+						values[i] = i
+					}
+
+					received := par.Reshape(values, rowLen)
+
+					assertEquals(t, l/rowLen, len(received))
+					for r := range received {
+						assertSliceEquals(t, values[r*rowLen:(r+1)*rowLen], received[r])
+						assertEquals(t, rowLen, cap(received[r]))
+					}
+					assertSliceEquals(t, values, par.Flatten(received))
+				})
+			}
+		}
+	})
+
+	t.Run("invalid row length", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.Reshape([]int{1, 2}, 0)
+		})
+	})
+
+	t.Run("uneven length", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.Reshape([]int{1, 2, 3}, 2)
+		})
+	})
+}
+
+func TestFlatten(t *testing.T) {
+	t.Run("different lengths", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.Flatten([][]int{{1, 2}, {3}})
+		})
+	})
+}
+
+func TestRepeat(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				expected := make([]string, l)
+				for i := range expected {
+					expected[i] = "x"
+				}
+
+				received := par.Repeat("x", l)
+
+				assertSliceEquals(t, expected, received)
+			})
+		}
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.Repeat(1, -1)
+		})
+	})
+}
+
+func TestGenerate(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				expected := make([]int, l)
+				for i := range expected {
+					expected[i] = i * i
+				}
+
+				received := par.Generate(l, func(i int) int {
+					return i * i
+				})
+
+				assertSliceEquals(t, expected, received)
+			})
+		}
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.Generate(-1, func(i int) int { return i })
+		})
+	})
+}
+
+func TestSplitBy(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				expected := make([][]int, 4)
+				for i := range values {
+					values[i] = i * 7
+					expected[values[i]%4] = append(expected[values[i]%4], values[i])
+				}
+
+				received := par.SplitBy(values, func(v int) int {
+					return v % 4
+				}, 4)
+
+				assertEquals(t, 4, len(received))
+				for c := range received {
+					assertSliceEquals(t, expected[c], received[c])
+				}
+			})
+		}
+	})
+
+	t.Run("invalid n", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.SplitBy([]int{1, 2}, func(v int) int { return 0 }, 0)
+		})
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.SplitBy([]int{1, 2}, func(v int) int { return v }, 2)
+		})
+	})
+}
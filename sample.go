@@ -0,0 +1,195 @@
+package par
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// SampleReservoir returns a uniform random sample of k of the values, without
+// replacement, so that every value has an equal probability of being
+// included. If there are no more than k values, all of them are returned.
+// The order of the returned values is random.
+//
+// Each partition maintains its own reservoir of up to k values, and the
+// reservoirs are then merged in parallel by drawing each value of the merged
+// reservoir from either of the reservoirs with a probability proportional to
+// the number of the remaining values each reservoir represents.
+//
+// The result is deterministic for a given seed and number of partitions.
+//
+// Panics if k is negative.
+func SampleReservoir[T any](values []T, k int, seed int64) []T {
+	if k < 0 {
+		panic("cannot sample a negative number of values")
+	}
+	if len(values) == 0 || k == 0 {
+		return []T(nil)
+	}
+
+	partitions, partitionSize := parts(values)
+	reservoirs := make([]reservoir[T], partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		r := reservoir[T]{
+			items: make([]T, 0, minInt(k, end-start)),
+			k:     k,
+			rng:   rand.New(rand.NewSource(int64(mix64(uint64(seed) ^ mix64(uint64(p)))))),
+		}
+		for _, v := range values[start:end] {
+			r.add(v)
+		}
+		reservoirs[p] = r
+	})
+
+	return mergeTree(reservoirs, reservoir[T].merge).items
+}
+
+// reservoir holds a uniform random sample of up to k of the values seen so
+// far.
+type reservoir[T any] struct {
+	items []T
+	seen  int
+	k     int
+	rng   *rand.Rand
+}
+
+// add adds v to the values seen by the reservoir using Algorithm R.
+func (r *reservoir[T]) add(v T) {
+	r.seen++
+	if len(r.items) < r.k {
+		r.items = append(r.items, v)
+		return
+	}
+	if j := r.rng.Int63n(int64(r.seen)); j < int64(r.k) {
+		r.items[j] = v
+	}
+}
+
+// merge returns a reservoir holding a uniform random sample of the union of
+// the values seen by r and o, consuming the items of both.
+func (r reservoir[T]) merge(o reservoir[T]) reservoir[T] {
+	n := minInt(r.k, len(r.items)+len(o.items))
+	result := reservoir[T]{
+		items: make([]T, 0, n),
+		seen:  r.seen + o.seen,
+		k:     r.k,
+		rng:   r.rng,
+	}
+	a, b := r.items, o.items
+	remainingA, remainingB := r.seen, o.seen
+	for len(result.items) < n {
+		if r.rng.Int63n(int64(remainingA+remainingB)) < int64(remainingA) {
+			a = result.take(a)
+			remainingA--
+		} else {
+			b = result.take(b)
+			remainingB--
+		}
+	}
+	return result
+}
+
+// take moves a random one of the items into the reservoir, returning the
+// remaining items.
+func (r *reservoir[T]) take(items []T) []T {
+	j := r.rng.Intn(len(items))
+	r.items = append(r.items, items[j])
+	items[j] = items[len(items)-1]
+	return items[:len(items)-1]
+}
+
+// SampleWeighted returns a weighted random sample of k of the values, without
+// replacement, so that the probability of each value being included is
+// proportional to its weight as returned by the weight function. Values with
+// a weight that is not positive are never included. If there are no more than
+// k values with a positive weight, all of them are returned. The returned
+// values maintain the order of the original values.
+//
+// The implementation uses the A-Res algorithm by Efraimidis and Spirakis:
+// each value is assigned a random key u^(1/w), where u is derived from the
+// seed and the index of the value, and the values with the k largest keys are
+// selected in parallel using a bounded heap per partition. The weight
+// function is called exactly once per value.
+//
+// The result is deterministic for a given seed, regardless of the number of
+// partitions.
+//
+// Panics if k is negative.
+func SampleWeighted[T any](values []T, weight func(T) float64, k int, seed int64) []T {
+	if k < 0 {
+		panic("cannot sample a negative number of values")
+	}
+
+	indices := sampleIndices(len(values), k, func(i int) (float64, bool) {
+		w := weight(values[i])
+		if !(w > 0) {
+			return 0, false
+		}
+		return math.Log(sampleUniform(seed, i)) / w, true
+	})
+	return Gather(values, indices)
+}
+
+// Sample returns n distinct values selected uniformly at random, without
+// replacement. If there are no more than n values, all of them are returned.
+// The returned values maintain the order of the original values.
+//
+// Each value is assigned a random key derived from the seed and the index of
+// the value, and the values with the n largest keys are selected in parallel
+// using a bounded heap per partition, so the values are never shuffled.
+//
+// The result is deterministic for a given seed, regardless of the number of
+// partitions.
+//
+// Panics if n is negative.
+func Sample[T any](values []T, n int, seed int64) []T {
+	if n < 0 {
+		panic("cannot sample a negative number of values")
+	}
+
+	indices := sampleIndices(len(values), n, func(i int) (float64, bool) {
+		return sampleUniform(seed, i), true
+	})
+	return Gather(values, indices)
+}
+
+// sampleIndices returns the indices of the k of n values with the largest
+// keys, as returned by the key function, in ascending order. Values for
+// which the key function returns false are never selected.
+func sampleIndices(n, k int, key func(i int) (float64, bool)) []int {
+	if n == 0 || k == 0 {
+		return []int(nil)
+	}
+
+	less := indexedLess(func(a, b float64) bool { return a > b })
+	partitions, partitionSize := partsOf(n)
+	heaps := make([]*boundedHeap[indexed[float64]], partitions)
+	forEachPart(partitions, partitionSize, n, func(p, start, end int) {
+		h := newBoundedHeap(k, less)
+		for i := start; i < end; i++ {
+			if v, ok := key(i); ok {
+				h.push(indexed[float64]{v, i})
+			}
+		}
+		heaps[p] = h
+	})
+	for _, h := range heaps[1:] {
+		for _, item := range h.items {
+			heaps[0].push(item)
+		}
+	}
+
+	indices := make([]int, len(heaps[0].items))
+	for i, item := range heaps[0].items {
+		indices[i] = item.index
+	}
+	sort.Ints(indices)
+	return indices
+}
+
+// sampleUniform returns a pseudo-random number in the range (0, 1) derived
+// from the seed and the index i.
+func sampleUniform(seed int64, i int) float64 {
+	x := mix64(uint64(seed) ^ mix64(uint64(i)))
+	return (float64(x>>11) + 0.5) / (1 << 53)
+}
@@ -0,0 +1,162 @@
+package par_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jussi-kalliokoski/par"
+)
+
+func TestSampleReservoir(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			for _, k := range []int{0, 1, 10} {
+				t.Run(fmt.Sprintf("len %d k %d", l, k), func(t *testing.T) {
+					values := make([]int, l)
+					for i := range values {
+						values[i] = i
+					}
+
+					received := par.SampleReservoir(values, k, int64(l))
+
+					assertEquals(t, minInt(k, l), len(received))
+					seen := make(map[int]bool)
+					for _, v := range received {
+						assertEquals(t, true, v >= 0 && v < l)
+						assertEquals(t, false, seen[v])
+						seen[v] = true
+					}
+				})
+			}
+		}
+	})
+
+	t.Run("uniform", func(t *testing.T) {
+		values := make([]int, 100)
+		for i := range values {
+			values[i] = i
+		}
+
+		counts := make([]int, len(values))
+		for seed := int64(0); seed < 2000; seed++ {
+			for _, v := range par.SampleReservoir(values, 10, seed) {
+				counts[v]++
+			}
+		}
+
+		for _, c := range counts {
+			assertEquals(t, true, c > 120 && c < 280)
+		}
+	})
+
+	t.Run("negative k", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.SampleReservoir([]int{1, 2}, -1, 0)
+		})
+	})
+}
+
+func TestSampleWeighted(t *testing.T) {
+	weight := func(v int) float64 {
+		return float64(v % 4)
+	}
+
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			for _, k := range []int{0, 1, 10} {
+				t.Run(fmt.Sprintf("len %d k %d", l, k), func(t *testing.T) {
+					values := make([]int, l)
+					var positive int
+					for i := range values {
+						values[i] = i
+						if weight(i) > 0 {
+							positive++
+						}
+					}
+
+					received := par.SampleWeighted(values, weight, k, int64(l))
+
+					assertEquals(t, minInt(k, positive), len(received))
+					for i, v := range received {
+						assertEquals(t, true, weight(v) > 0)
+						assertEquals(t, true, i == 0 || v > received[i-1])
+					}
+					assertSliceEquals(t, received, par.SampleWeighted(values, weight, k, int64(l)))
+				})
+			}
+		}
+	})
+
+	t.Run("weighted", func(t *testing.T) {
+		values := make([]int, 100)
+		for i := range values {
+			values[i] = i
+		}
+
+		counts := make([]int, 4)
+		for seed := int64(0); seed < 1000; seed++ {
+			for _, v := range par.SampleWeighted(values, weight, 1, seed) {
+				counts[v%4]++
+			}
+		}
+
+		assertEquals(t, 0, counts[0])
+		assertEquals(t, true, counts[1] > 100 && counts[1] < 230)
+		assertEquals(t, true, counts[2] > 260 && counts[2] < 400)
+		assertEquals(t, true, counts[3] > 430 && counts[3] < 570)
+	})
+
+	t.Run("negative k", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.SampleWeighted([]int{1, 2}, weight, -1, 0)
+		})
+	})
+}
+
+func TestSample(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			for _, n := range []int{0, 1, 10} {
+				t.Run(fmt.Sprintf("len %d n %d", l, n), func(t *testing.T) {
+					values := make([]int, l)
+					for i := range values {
+						values[i] = i
+					}
+
+					received := par.Sample(values, n, int64(l))
+
+					assertEquals(t, minInt(n, l), len(received))
+					for i, v := range received {
+						assertEquals(t, true, v >= 0 && v < l)
+						assertEquals(t, true, i == 0 || v > received[i-1])
+					}
+					assertSliceEquals(t, received, par.Sample(values, n, int64(l)))
+				})
+			}
+		}
+	})
+
+	t.Run("uniform", func(t *testing.T) {
+		values := make([]int, 100)
+		for i := range values {
+			values[i] = i
+		}
+
+		counts := make([]int, len(values))
+		for seed := int64(0); seed < 2000; seed++ {
+			for _, v := range par.Sample(values, 10, seed) {
+				counts[v]++
+			}
+		}
+
+		for _, c := range counts {
+			assertEquals(t, true, c > 120 && c < 280)
+		}
+	})
+
+	t.Run("negative n", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.Sample([]int{1, 2}, -1, 0)
+		})
+	})
+}
@@ -0,0 +1,150 @@
+package par
+
+// Scan returns the inclusive prefix scan of the values, i.e. a slice where
+// each item is the result of combining all of the values up to and including
+// the one at the same index with op. For example, with addition as op, Scan
+// returns the cumulative sums of the values.
+//
+// Internally, the implementation uses the two-pass parallel prefix
+// algorithm: each partition is scanned in parallel, then the totals of the
+// partitions are scanned serially, then the scanned total of the preceding
+// partitions is combined into each item of each partition in parallel. The
+// op function thus needs to be associative, but not commutative.
+func Scan[T any](values []T, op func(T, T) T) []T {
+	if len(values) == 0 {
+		return []T(nil)
+	}
+
+	partitions, partitionSize := parts(values)
+	result := make([]T, len(values))
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		acc := values[start]
+		result[start] = acc
+		for i := start + 1; i < end; i++ {
+			acc = op(acc, values[i])
+			result[i] = acc
+		}
+	})
+
+	carries := make([]T, partitions)
+	for p := 1; p < partitions; p++ {
+		carries[p] = result[p*partitionSize-1]
+		if p > 1 {
+			carries[p] = op(carries[p-1], carries[p])
+		}
+	}
+
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		if p == 0 {
+			return
+		}
+		for i := start; i < end; i++ {
+			result[i] = op(carries[p], result[i])
+		}
+	})
+
+	return result
+}
+
+// ScanExclusive returns the exclusive prefix scan of the values, i.e. a slice
+// where each item is the result of combining identity and all of the values
+// before the one at the same index with op. For example, with addition as op
+// and zero as identity, ScanExclusive returns the offsets of the values when
+// they are used as lengths.
+//
+// The implementation is the same as with Scan, and op needs to be
+// associative, but not commutative. The identity must satisfy
+// op(identity, v) == v for every v.
+func ScanExclusive[T any](values []T, op func(T, T) T, identity T) []T {
+	if len(values) == 0 {
+		return []T(nil)
+	}
+
+	partitions, partitionSize := parts(values)
+	result := make([]T, len(values))
+	totals := make([]T, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		acc := identity
+		for i := start; i < end; i++ {
+			result[i] = acc
+			acc = op(acc, values[i])
+		}
+		totals[p] = acc
+	})
+
+	carries := make([]T, partitions)
+	carries[0] = identity
+	for p := 1; p < partitions; p++ {
+		carries[p] = op(carries[p-1], totals[p-1])
+	}
+
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		if p == 0 {
+			return
+		}
+		for i := start; i < end; i++ {
+			result[i] = op(carries[p], result[i])
+		}
+	})
+
+	return result
+}
+
+// SegmentedScan returns the inclusive prefix scan of the values as with Scan,
+// except that the accumulation is reset at each value for which isBoundary
+// returns true, so that each segment starting from a boundary is scanned
+// independently. The first value always starts a segment.
+//
+// The implementation is the same as with Scan, except that only the items of
+// a partition before its first boundary are combined with the scanned total
+// of the preceding partitions, so segments that span partition boundaries are
+// handled correctly.
+func SegmentedScan[T any](values []T, isBoundary func(T) bool, op func(T, T) T) []T {
+	if len(values) == 0 {
+		return []T(nil)
+	}
+
+	partitions, partitionSize := parts(values)
+	result := make([]T, len(values))
+	firstBoundaries := make([]int, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		firstBoundary := end
+		if p == 0 {
+			firstBoundary = start
+		}
+		acc := values[start]
+		result[start] = acc
+		if isBoundary(values[start]) {
+			firstBoundary = start
+		}
+		for i := start + 1; i < end; i++ {
+			if isBoundary(values[i]) {
+				acc = values[i]
+				firstBoundary = minInt(firstBoundary, i)
+			} else {
+				acc = op(acc, values[i])
+			}
+			result[i] = acc
+		}
+		firstBoundaries[p] = firstBoundary
+	})
+
+	carries := make([]T, partitions)
+	for p := 1; p < partitions; p++ {
+		carries[p] = result[p*partitionSize-1]
+		if p > 1 && firstBoundaries[p-1] == p*partitionSize {
+			carries[p] = op(carries[p-1], carries[p])
+		}
+	}
+
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		if p == 0 {
+			return
+		}
+		for i := start; i < firstBoundaries[p]; i++ {
+			result[i] = op(carries[p], result[i])
+		}
+	})
+
+	return result
+}
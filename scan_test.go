@@ -0,0 +1,85 @@
+package par_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jussi-kalliokoski/par"
+)
+
+func TestScan(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]string, l)
+				expected := make([]string, l)
+				var acc string
+				for i := range values {
+					values[i] = string(rune('a' + i%26))
+					acc += values[i]
+					expected[i] = acc
+				}
+
+				received := par.Scan(values, func(a, b string) string {
+					return a + b
+				})
+
+				assertSliceEquals(t, expected, received)
+			})
+		}
+	})
+}
+
+func TestScanExclusive(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]string, l)
+				expected := make([]string, l)
+				var acc string
+				for i := range values {
+					values[i] = string(rune('a' + i%26))
+					expected[i] = acc
+					acc += values[i]
+				}
+
+				received := par.ScanExclusive(values, func(a, b string) string {
+					return a + b
+				}, "")
+
+				assertSliceEquals(t, expected, received)
+			})
+		}
+	})
+}
+
+func TestSegmentedScan(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			for _, every := range []int{1, 7, 1000} {
+				t.Run(fmt.Sprintf("len %d reset every %d", l, every), func(t *testing.T) {
+					values := make([]string, l)
+					expected := make([]string, l)
+					var acc string
+					for i := range values {
+						values[i] = string(rune('a' + i%26))
+						if i%every == every-1 {
+							values[i] = "|"
+							acc = ""
+						}
+						acc += values[i]
+						expected[i] = acc
+					}
+
+					received := par.SegmentedScan(values, func(v string) bool {
+						return v == "|"
+					}, func(a, b string) string {
+						return a + b
+					})
+
+					assertSliceEquals(t, expected, received)
+				})
+			}
+		}
+	})
+}
@@ -0,0 +1,313 @@
+package par
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// Find returns the first value for which the predicate returns true, along
+// with its index, and a boolean indicating if any such value was found.
+//
+// The result is deterministic: the match with the lowest index is always
+// returned, even though the partitions are searched in parallel. A partition
+// terminates upon the first encountered match, or as soon as a match with a
+// lower index is found by another partition, and as such, the predicate may
+// not be called for every value.
+func Find[T any](values []T, predicate func(T) bool) (value T, index int, ok bool) {
+	index = findFirst(len(values), func(i int) bool { return predicate(values[i]) })
+	if index < 0 {
+		return value, -1, false
+	}
+	return values[index], index, true
+}
+
+// findFirst returns the lowest index in the range [0, n) for which the
+// predicate returns true, or -1 if there is none.
+func findFirst(n int, predicate func(i int) bool) int {
+	if n == 0 {
+		return -1
+	}
+
+	partitions, partitionSize := partsOf(n)
+	best := int64(n)
+	forEachPart(partitions, partitionSize, n, func(p, start, end int) {
+		for i := start; i < end; i++ {
+			if int64(i) >= atomic.LoadInt64(&best) {
+				return
+			}
+			if predicate(i) {
+				for {
+					current := atomic.LoadInt64(&best)
+					if int64(i) >= current || atomic.CompareAndSwapInt64(&best, current, int64(i)) {
+						return
+					}
+				}
+			}
+		}
+	})
+
+	if best == int64(n) {
+		return -1
+	}
+	return int(best)
+}
+
+// FindLast returns the last value for which the predicate returns true, along
+// with its index, and a boolean indicating if any such value was found.
+//
+// The result is deterministic: the match with the highest index is always
+// returned, even though the partitions are searched in parallel. Each
+// partition is searched from its end, and terminates upon the first
+// encountered match, or as soon as a match with a higher index is found by
+// another partition, and as such, the predicate may not be called for every
+// value.
+func FindLast[T any](values []T, predicate func(T) bool) (value T, index int, ok bool) {
+	index = findLast(len(values), func(i int) bool { return predicate(values[i]) })
+	if index < 0 {
+		return value, -1, false
+	}
+	return values[index], index, true
+}
+
+// findLast returns the highest index in the range [0, n) for which the
+// predicate returns true, or -1 if there is none.
+func findLast(n int, predicate func(i int) bool) int {
+	if n == 0 {
+		return -1
+	}
+
+	partitions, partitionSize := partsOf(n)
+	best := int64(-1)
+	forEachPart(partitions, partitionSize, n, func(p, start, end int) {
+		for i := end - 1; i >= start; i-- {
+			if int64(i) <= atomic.LoadInt64(&best) {
+				return
+			}
+			if predicate(i) {
+				for {
+					current := atomic.LoadInt64(&best)
+					if int64(i) <= current || atomic.CompareAndSwapInt64(&best, current, int64(i)) {
+						return
+					}
+				}
+			}
+		}
+	})
+
+	return int(best)
+}
+
+// FindN returns the first n values for which the predicate returns true, in
+// the order of the original values. If there are fewer than n matches, all of
+// the matches are returned.
+//
+// Each partition collects at most n matches. Once the partitions preceding a
+// partition are known to hold at least n matches in total, the partition is
+// cancelled, and as such, the predicate may not be called for every value.
+//
+// Panics if n is negative.
+func FindN[T any](values []T, predicate func(T) bool, n int) []T {
+	if n < 0 {
+		panic("cannot find a negative number of values")
+	}
+	if len(values) == 0 || n == 0 {
+		return []T(nil)
+	}
+
+	partitions, partitionSize := parts(values)
+	matches := make([][]T, partitions)
+	cutoff := int64(partitions)
+	done := make(chan int, partitions) // buffer to prevent processors from blocking.
+	for p := 0; p < partitions; p++ {
+		start := partitionSize * p
+		end := start + partitionSize
+		if p == partitions-1 {
+			end = len(values)
+		}
+		go func(p, start, end int) {
+			defer func() { done <- p }()
+			for i := start; i < end && int64(p) < atomic.LoadInt64(&cutoff); i++ {
+				if predicate(values[i]) {
+					matches[p] = append(matches[p], values[i])
+					if len(matches[p]) == n {
+						return
+					}
+				}
+			}
+		}(p, start, end)
+	}
+
+	// Wait for all the processors to exit, cancelling the partitions after
+	// the leading completed partitions as soon as they hold enough matches.
+	completed := make([]bool, partitions)
+	var leading, found int
+	for received := 0; received < partitions; received++ {
+		completed[<-done] = true
+		for found < n && leading < partitions && completed[leading] {
+			found += len(matches[leading])
+			leading++
+			if found >= n {
+				atomic.StoreInt64(&cutoff, int64(leading))
+			}
+		}
+	}
+
+	result := Concat(matches[:leading])
+	if len(result) > n {
+		result = result[:n]
+	}
+	return result
+}
+
+// Contains returns a boolean indicating if v is present in values.
+//
+// A partition will terminate upon the first encountered occurrence of v, and
+// as such, not every value may be compared.
+func Contains[T comparable](values []T, v T) bool {
+	return Any(values, func(x T) bool { return x == v })
+}
+
+// Index returns the index of the first occurrence of v in values, or -1 if v
+// is not present in values.
+//
+// The result is deterministic: the lowest index is always returned, even
+// though the partitions are searched in parallel.
+func Index[T comparable](values []T, v T) int {
+	return findFirst(len(values), func(i int) bool { return values[i] == v })
+}
+
+// FindAllIndices returns the indices of all the values for which the
+// predicate returns true, in ascending order.
+//
+// The implementation works like Filter, but produces the indices of the
+// matching values instead of copies of them, which avoids copying large
+// values when only their positions are needed.
+func FindAllIndices[T any](values []T, predicate func(T) bool) []int {
+	if len(values) == 0 {
+		return []int(nil)
+	}
+
+	jobs, totalCount := filterMark(len(values), func(start, end int, bitmap []uint64) int {
+		var count int
+		for i := start; i < end; i++ {
+			if predicate(values[i]) {
+				pos := i - start
+				bitmap[pos/64] |= 1 << (pos % 64)
+				count++
+			}
+		}
+		return count
+	})
+
+	return filterIndices(jobs, totalCount)
+}
+
+// AnyValue returns a value for which the predicate returns true, and a
+// boolean indicating if any such value was found.
+//
+// The returned value is not necessarily the first match: any partition that
+// encounters a match may provide the result. A partition will terminate upon
+// the first encountered match, and as such, the predicate may not be called
+// for every value. Use Find to get the first match deterministically.
+func AnyValue[T any](values []T, predicate func(T) bool) (value T, ok bool) {
+	if len(values) == 0 {
+		return value, false
+	}
+
+	partitions, partitionSize := parts(values)
+	found := int64(-1)
+	done := make(chan struct{})
+	var once sync.Once
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		for i := start; i < end; i++ {
+			select {
+			case <-done:
+				return
+			default:
+				if predicate(values[i]) {
+					once.Do(func() {
+						atomic.StoreInt64(&found, int64(i))
+						close(done) // trigger early return of remaining processors.
+					})
+					return
+				}
+			}
+		}
+	})
+
+	if found < 0 {
+		return value, false
+	}
+	return values[found], true
+}
+
+// AllWithViolation returns a boolean indicating if predicate returns true for
+// all of the values, and if not, the index and the value of the first value
+// for which the predicate returns false. If all the values pass, the index
+// is -1.
+//
+// The result is deterministic: the violation with the lowest index is always
+// returned, as with Find.
+func AllWithViolation[T any](values []T, predicate func(T) bool) (ok bool, index int, value T) {
+	value, index, found := Find(values, func(v T) bool { return !predicate(v) })
+	return !found, index, value
+}
+
+// SearchSorted returns, for each needle, the index of the first value in
+// sorted that is not less than the needle as defined by cmp, i.e. the index
+// at which the needle would be inserted to keep sorted in order. The cmp
+// function returns a negative number when a < b, a positive number when
+// a > b, and zero when a == b.
+//
+// The binary searches are distributed across partitions of the needles, and
+// the returned slice maintains the order of the needles. The values in
+// sorted must be sorted in ascending order as defined by cmp.
+func SearchSorted[T any](sorted, needles []T, cmp func(a, b T) int) []int {
+	return Map(needles, func(needle T) int {
+		return sort.Search(len(sorted), func(i int) bool {
+			return cmp(sorted[i], needle) >= 0
+		})
+	})
+}
+
+// ContainsAny returns a boolean indicating if any of the needles is present
+// in values.
+//
+// A set of the needles is built once, and the values are then scanned in
+// parallel, with a partition terminating upon the first encountered needle.
+func ContainsAny[T comparable](values, needles []T) bool {
+	set := make(map[T]struct{}, len(needles))
+	for _, n := range needles {
+		set[n] = struct{}{}
+	}
+	return Any(values, func(v T) bool {
+		_, ok := set[v]
+		return ok
+	})
+}
+
+// ContainsAll returns a boolean indicating if all of the needles are present
+// in values.
+//
+// A set of the needles is built once, and the values are then scanned in
+// parallel, with all the partitions terminating as soon as every needle has
+// been encountered.
+func ContainsAll[T comparable](values, needles []T) bool {
+	set := make(map[T]int, len(needles))
+	for _, n := range needles {
+		if _, ok := set[n]; !ok {
+			set[n] = len(set)
+		}
+	}
+	if len(set) == 0 {
+		return true
+	}
+
+	found := make([]uint32, len(set))
+	remaining := int64(len(set))
+	return Any(values, func(v T) bool {
+		idx, ok := set[v]
+		return ok && atomic.CompareAndSwapUint32(&found[idx], 0, 1) && atomic.AddInt64(&remaining, -1) == 0
+	})
+}
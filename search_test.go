@@ -0,0 +1,341 @@
+package par_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/jussi-kalliokoski/par"
+)
+
+func TestFind(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		t.Run("len 0", func(t *testing.T) {
+			_, index, ok := par.Find([]int(nil), func(int) bool {
+				return true
+			})
+
+			assertEquals(t, -1, index)
+			assertEquals(t, false, ok)
+		})
+
+		for _, l := range testLengths(1) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				t.Run("found", func(t *testing.T) {
+					values := make([]int, l)
+					rand.Seed(int64(l))
+					first := rand.Intn(l)
+					for i := first; i < l; i += 1 + rand.Intn(10) {
+						values[i] = i + 1
+					}
+
+					value, index, ok := par.Find(values, func(v int) bool {
+						return v > 0
+					})
+
+					assertEquals(t, true, ok)
+					assertEquals(t, first, index)
+					assertEquals(t, first+1, value)
+				})
+
+				t.Run("not found", func(t *testing.T) {
+					values := make([]int, l)
+
+					value, index, ok := par.Find(values, func(v int) bool {
+						return v > 0
+					})
+
+					assertEquals(t, false, ok)
+					assertEquals(t, -1, index)
+					assertEquals(t, 0, value)
+				})
+			})
+		}
+	})
+}
+
+func TestFindLast(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		t.Run("len 0", func(t *testing.T) {
+			_, index, ok := par.FindLast([]int(nil), func(int) bool {
+				return true
+			})
+
+			assertEquals(t, -1, index)
+			assertEquals(t, false, ok)
+		})
+
+		for _, l := range testLengths(1) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				t.Run("found", func(t *testing.T) {
+					values := make([]int, l)
+					rand.Seed(int64(l))
+					last := rand.Intn(l)
+					for i := last; i >= 0; i -= 1 + rand.Intn(10) {
+						values[i] = i + 1
+					}
+
+					value, index, ok := par.FindLast(values, func(v int) bool {
+						return v > 0
+					})
+
+					assertEquals(t, true, ok)
+					assertEquals(t, last, index)
+					assertEquals(t, last+1, value)
+				})
+
+				t.Run("not found", func(t *testing.T) {
+					values := make([]int, l)
+
+					value, index, ok := par.FindLast(values, func(v int) bool {
+						return v > 0
+					})
+
+					assertEquals(t, false, ok)
+					assertEquals(t, -1, index)
+					assertEquals(t, 0, value)
+				})
+			})
+		}
+	})
+}
+
+func TestFindN(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			for _, n := range []int{0, 1, 5, 1000} {
+				t.Run(fmt.Sprintf("len %d n %d", l, n), func(t *testing.T) {
+					values := make([]int, l)
+					expected := []int(nil)
+					for i := range values {
+						values[i] = i
+						if i%3 == 2 && len(expected) < n {
+							expected = append(expected, i)
+						}
+					}
+
+					received := par.FindN(values, func(v int) bool {
+						return v%3 == 2
+					}, n)
+
+					assertSliceEquals(t, expected, received)
+				})
+			}
+		}
+	})
+
+	t.Run("negative n", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.FindN([]int{1}, func(int) bool { return true }, -1)
+		})
+	})
+}
+
+func TestContains(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				for i := range values {
+					values[i] = i
+				}
+
+				assertEquals(t, l > 0, par.Contains(values, l/2))
+				assertEquals(t, false, par.Contains(values, l))
+			})
+		}
+	})
+}
+
+func TestIndex(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				for i := range values {
+					values[i] = i % 10
+				}
+				expected := -1
+				if l > 7 {
+					expected = 7
+				}
+
+				assertEquals(t, expected, par.Index(values, 7))
+				assertEquals(t, -1, par.Index(values, 10))
+			})
+		}
+	})
+}
+
+func TestFindAllIndices(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				expected := []int(nil)
+				for i := range values {
+					values[i] = i * 3
+					if values[i]%2 == 0 {
+						expected = append(expected, i)
+					}
+				}
+
+				received := par.FindAllIndices(values, func(v int) bool {
+					return v%2 == 0
+				})
+
+				assertSliceEquals(t, expected, received)
+			})
+		}
+	})
+}
+
+func TestAnyValue(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		t.Run("len 0", func(t *testing.T) {
+			_, ok := par.AnyValue([]int(nil), func(int) bool {
+				return true
+			})
+
+			assertEquals(t, false, ok)
+		})
+
+		for _, l := range testLengths(1) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				t.Run("found", func(t *testing.T) {
+					values := make([]int, l)
+					for i := range values {
+						values[i] = i
+					}
+
+					value, ok := par.AnyValue(values, func(v int) bool {
+						return v%5 == 0
+					})
+
+					assertEquals(t, true, ok)
+					assertEquals(t, 0, value%5)
+				})
+
+				t.Run("not found", func(t *testing.T) {
+					values := make([]int, l)
+					for i := range values {
+						values[i] = i
+					}
+
+					_, ok := par.AnyValue(values, func(v int) bool {
+						return v == l
+					})
+
+					assertEquals(t, false, ok)
+				})
+			})
+		}
+	})
+}
+
+func TestAllWithViolation(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				t.Run("true", func(t *testing.T) {
+					values := make([]int, l)
+					for i := range values {
+						values[i] = i
+					}
+
+					ok, index, _ := par.AllWithViolation(values, func(v int) bool {
+						return v < l
+					})
+
+					assertEquals(t, true, ok)
+					assertEquals(t, -1, index)
+				})
+
+				if l == 0 {
+					return
+				}
+
+				t.Run("false", func(t *testing.T) {
+					values := make([]int, l)
+					for i := range values {
+						values[i] = i
+					}
+					rand.Seed(int64(l))
+					first := rand.Intn(l)
+					for i := first; i < l; i += 1 + rand.Intn(10) {
+						values[i] = -i - 1
+					}
+
+					ok, index, value := par.AllWithViolation(values, func(v int) bool {
+						return v >= 0
+					})
+
+					assertEquals(t, false, ok)
+					assertEquals(t, first, index)
+					assertEquals(t, -first-1, value)
+				})
+			})
+		}
+	})
+}
+
+func TestSearchSorted(t *testing.T) {
+	cmp := func(a, b int) int {
+		return a - b
+	}
+
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				sorted := make([]int, l)
+				for i := range sorted {
+					sorted[i] = i * 2
+				}
+				needles := make([]int, l*2+2)
+				expected := make([]int, len(needles))
+				for i := range needles {
+					needles[i] = len(needles) - 1 - i
+					expected[i] = minInt((needles[i]+1)/2, l)
+				}
+
+				received := par.SearchSorted(sorted, needles, cmp)
+
+				assertSliceEquals(t, expected, received)
+			})
+		}
+	})
+}
+
+func TestContainsAny(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				for i := range values {
+					values[i] = i
+				}
+
+				assertEquals(t, l > 0, par.ContainsAny(values, []int{-1, l - 1, l}))
+				assertEquals(t, false, par.ContainsAny(values, []int{-1, l}))
+				assertEquals(t, false, par.ContainsAny(values, nil))
+			})
+		}
+	})
+}
+
+func TestContainsAll(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				for i := range values {
+					values[i] = i
+				}
+
+				assertEquals(t, l > 0, par.ContainsAll(values, []int{0, l - 1, l / 2, 0}))
+				assertEquals(t, false, par.ContainsAll(values, []int{0, l}))
+				assertEquals(t, true, par.ContainsAll(values, nil))
+			})
+		}
+	})
+}
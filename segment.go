@@ -0,0 +1,179 @@
+package par
+
+import (
+	"sort"
+)
+
+// SegmentReduce reduces each segment of values to a single value by
+// repeatedly applying an accumulator, returning the results in the order of
+// the segments.
+//
+// The segments are defined by segmentOffsets, which contains the start index
+// of each segment in ascending order: segment i spans from
+// segmentOffsets[i] up to segmentOffsets[i+1], and the last segment spans up
+// to the end of values. Values before the first offset are not part of any
+// segment.
+//
+// The accumulator is applied to the elements of a segment in order, in the
+// same way as with a serial reduce. Each segment is reduced entirely by the
+// partition that contains its start index, so a segment much longer than a
+// partition is reduced serially and the work may be unevenly distributed
+// when the segment sizes vary.
+//
+// Panics if the offsets are not strictly ascending or are out of bounds, as
+// an empty segment cannot be reduced.
+func SegmentReduce[T any](values []T, segmentOffsets []int, accumulator func(T, T) T) []T {
+	if len(segmentOffsets) == 0 {
+		return []T(nil)
+	}
+	for i, offset := range segmentOffsets {
+		if offset < 0 || offset >= len(values) {
+			panic("segment offset out of bounds")
+		}
+		if i > 0 && offset <= segmentOffsets[i-1] {
+			panic("cannot reduce an empty segment")
+		}
+	}
+
+	partitions, partitionSize := parts(values)
+	result := make([]T, len(segmentOffsets))
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		first := sort.SearchInts(segmentOffsets, start)
+		last := len(segmentOffsets)
+		if p < partitions-1 {
+			last = sort.SearchInts(segmentOffsets, end)
+		}
+		for s := first; s < last; s++ {
+			segmentEnd := len(values)
+			if s+1 < len(segmentOffsets) {
+				segmentEnd = segmentOffsets[s+1]
+			}
+			v := values[segmentOffsets[s]]
+			for i := segmentOffsets[s] + 1; i < segmentEnd; i++ {
+				v = accumulator(v, values[i])
+			}
+			result[s] = v
+		}
+	})
+
+	return result
+}
+
+// ReduceByRun reduces each run of consecutive values that belong to the same
+// group to a single value by repeatedly applying reduce, returning the
+// results in the order of the runs. This is the equivalent of reducing by
+// key for values that are already grouped, e.g. sorted by key.
+//
+// The sameGroup function is called with each pair of adjacent values, and
+// returns true if they belong to the same group.
+//
+// Each partition reduces the runs within it in order, then the runs that
+// span partition boundaries are stitched together by reducing the result of
+// the earlier part of the run with the result of the later part, so reduce
+// needs to be associative.
+func ReduceByRun[T any](values []T, sameGroup func(a, b T) bool, reduce func(T, T) T) []T {
+	if len(values) == 0 {
+		return []T(nil)
+	}
+
+	partitions, partitionSize := parts(values)
+	runs := make([][]T, partitions)
+	continued := make([]bool, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		continued[p] = p > 0 && sameGroup(values[start-1], values[start])
+		r := []T{values[start]}
+		for i := start + 1; i < end; i++ {
+			if sameGroup(values[i-1], values[i]) {
+				r[len(r)-1] = reduce(r[len(r)-1], values[i])
+			} else {
+				r = append(r, values[i])
+			}
+		}
+		runs[p] = r
+	})
+
+	var last *T
+	for p := range runs {
+		if continued[p] {
+			*last = reduce(*last, runs[p][0])
+			runs[p] = runs[p][1:]
+		}
+		if len(runs[p]) > 0 {
+			last = &runs[p][len(runs[p])-1]
+		}
+	}
+
+	return Concat(runs)
+}
+
+// LongestRun returns the start index and the length of the longest run of
+// consecutive values for which the predicate returns true. Ties are broken by
+// the order of the original values, so the first of the longest runs is
+// returned. If the predicate returns false for every value, 0, 0 is returned.
+//
+// Each partition is summarized by the lengths of its matching prefix and
+// suffix and its longest run, and the summaries of adjacent partitions are
+// then merged in parallel, joining the suffix of the earlier partition with
+// the prefix of the later one. The predicate is called exactly once per
+// value.
+func LongestRun[T any](values []T, predicate func(T) bool) (start, length int) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+
+	type summary struct {
+		start, n       int
+		prefix, suffix int
+		bestStart      int
+		bestLen        int
+	}
+	partitions, partitionSize := parts(values)
+	summaries := make([]summary, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		s := summary{start: start, n: end - start}
+		var current int
+		for i := start; i < end; i++ {
+			if !predicate(values[i]) {
+				current = 0
+				continue
+			}
+			current++
+			if current == i-start+1 {
+				s.prefix = current
+			}
+			if current > s.bestLen {
+				s.bestStart, s.bestLen = i-current+1, current
+			}
+		}
+		s.suffix = current
+		summaries[p] = s
+	})
+
+	s := mergeTree(summaries, func(a, b summary) summary {
+		r := summary{
+			start:     a.start,
+			n:         a.n + b.n,
+			prefix:    a.prefix,
+			suffix:    b.suffix,
+			bestStart: a.bestStart,
+			bestLen:   a.bestLen,
+		}
+		if a.prefix == a.n {
+			r.prefix = a.n + b.prefix
+		}
+		if b.suffix == b.n {
+			r.suffix = b.n + a.suffix
+		}
+		if joined := a.suffix + b.prefix; joined > r.bestLen {
+			r.bestStart, r.bestLen = b.start-a.suffix, joined
+		}
+		if b.bestLen > r.bestLen {
+			r.bestStart, r.bestLen = b.bestStart, b.bestLen
+		}
+		return r
+	})
+	if s.bestLen == 0 {
+		return 0, 0
+	}
+	return s.bestStart, s.bestLen
+}
@@ -0,0 +1,177 @@
+package par_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/jussi-kalliokoski/par"
+)
+
+func TestSegmentReduce(t *testing.T) {
+	values := make([]int, 10000)
+	for i := range values {
+		values[i] = i
+	}
+
+	t.Run("lengths", func(t *testing.T) {
+		tests := []int(nil)
+		for i := 1; i < 128; i++ {
+			tests = append(tests, i)
+		}
+		for i := 128; i < 2048; i = i << 1 {
+			tests = append(tests, i)
+		}
+		for _, l := range tests {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				rand.Seed(int64(l))
+				offsets := []int{0}
+				for i := 1; i < l; i++ {
+					if rand.Intn(4) == 0 {
+						offsets = append(offsets, i)
+					}
+				}
+				expected := make([]int, len(offsets))
+				for s, offset := range offsets {
+					end := l
+					if s+1 < len(offsets) {
+						end = offsets[s+1]
+					}
+					for _, v := range values[offset:end] {
+						expected[s] += v
+					}
+				}
+
+				received := par.SegmentReduce(values[:l], offsets, func(a, b int) int {
+					return a + b
+				})
+
+				assertSliceEquals(t, expected, received)
+			})
+		}
+	})
+
+	t.Run("leading values outside segments", func(t *testing.T) {
+		received := par.SegmentReduce([]int{1, 2, 3, 4, 5}, []int{2, 4}, func(a, b int) int {
+			return a + b
+		})
+
+		assertSliceEquals(t, []int{7, 5}, received)
+	})
+
+	t.Run("no segments", func(t *testing.T) {
+		received := par.SegmentReduce([]int{1, 2, 3}, nil, func(a, b int) int {
+			return a + b
+		})
+
+		assertEquals(t, 0, len(received))
+	})
+
+	t.Run("empty segment", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.SegmentReduce([]int{1, 2, 3}, []int{0, 1, 1}, func(a, b int) int {
+				return a + b
+			})
+		})
+	})
+
+	t.Run("out of bounds", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.SegmentReduce([]int{1, 2, 3}, []int{0, 3}, func(a, b int) int {
+				return a + b
+			})
+		})
+	})
+}
+
+func TestReduceByRun(t *testing.T) {
+	type entry struct {
+		Key   int
+		Count int
+	}
+
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				rand.Seed(int64(l))
+				values := make([]entry, l)
+				expected := []entry(nil)
+				var key int
+				for i := range values {
+					if rand.Intn(8) == 0 {
+						key++
+					}
+					values[i] = entry{key, i}
+					if i == 0 || key != values[i-1].Key {
+						expected = append(expected, entry{key, 0})
+					}
+					expected[len(expected)-1].Count += i
+				}
+
+				received := par.ReduceByRun(values, func(a, b entry) bool {
+					return a.Key == b.Key
+				}, func(a, b entry) entry {
+					return entry{a.Key, a.Count + b.Count}
+				})
+
+				assertSliceEquals(t, expected, received)
+			})
+		}
+	})
+
+	t.Run("single run", func(t *testing.T) {
+		values := make([]int, 1000)
+		for i := range values {
+			values[i] = 1
+		}
+
+		received := par.ReduceByRun(values, func(a, b int) bool {
+			return a == b
+		}, func(a, b int) int {
+			return a + b
+		})
+
+		assertSliceEquals(t, []int{1000}, received)
+	})
+}
+
+func TestLongestRun(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				rng := rand.New(rand.NewSource(int64(l)))
+				values := make([]bool, l)
+				var expectedStart, expectedLength, current int
+				for i := range values {
+					values[i] = rng.Intn(5) > 0
+					if !values[i] {
+						current = 0
+						continue
+					}
+					current++
+					if current > expectedLength {
+						expectedStart, expectedLength = i-current+1, current
+					}
+				}
+
+				start, length := par.LongestRun(values, func(v bool) bool {
+					return v
+				})
+
+				assertEquals(t, expectedStart, start)
+				assertEquals(t, expectedLength, length)
+			})
+		}
+	})
+
+	t.Run("all matching", func(t *testing.T) {
+		values := make([]int, 1000)
+
+		start, length := par.LongestRun(values, func(v int) bool {
+			return v == 0
+		})
+
+		assertEquals(t, 0, start)
+		assertEquals(t, 1000, length)
+	})
+}
@@ -0,0 +1,151 @@
+package par
+
+import (
+	"math"
+	"sort"
+)
+
+// NthElement returns the value that would be at index n if the values were
+// sorted according to less, without sorting the values.
+//
+// Internally, the implementation uses a parallel quickselect: the values
+// less than and greater than a pivot are counted in parallel, then the side
+// containing the nth value is filtered into a new slice in parallel, until
+// the nth value is the pivot or few enough values remain to sort them. The
+// values slice is not modified.
+//
+// The less function must define a strict weak ordering, as with sort.Slice.
+// Otherwise the result is undefined, e.g. for floating-point values that
+// include NaN when compared with the < operator.
+//
+// Panics if n is outside the range [0, len(values)).
+func NthElement[T any](values []T, n int, less func(a, b T) bool) T {
+	if n < 0 || n >= len(values) {
+		panic("cannot select an element outside the values")
+	}
+
+	keep := func(T) bool { return true }
+	v, _ := nthElement(values, keep, func(int) int { return n }, less)
+	return v
+}
+
+// nthElement is NthElement for the values for which keep returns true. The
+// other values are dropped during the first round of the selection, which
+// also counts the kept values and resolves n from that count using index.
+// Returns false if no values are kept.
+func nthElement[T any](values []T, keep func(T) bool, index func(count int) int, less func(a, b T) bool) (T, bool) {
+	var n int
+	filtered := false
+	for len(values) > nthElementSortThreshold {
+		keepAll := filtered
+		pivot := medianOfThree(values[0], values[len(values)/2], values[len(values)-1], less)
+
+		partitions, partitionSize := parts(values)
+		keptCounts := make([]int, partitions)
+		lessCounts := make([]int, partitions)
+		greaterCounts := make([]int, partitions)
+		forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+			for _, v := range values[start:end] {
+				if !keepAll && !keep(v) {
+					continue
+				}
+				keptCounts[p]++
+				if less(v, pivot) {
+					lessCounts[p]++
+				} else if less(pivot, v) {
+					greaterCounts[p]++
+				}
+			}
+		})
+		var keptCount, lessCount, greaterCount int
+		for p := range keptCounts {
+			keptCount += keptCounts[p]
+			lessCount += lessCounts[p]
+			greaterCount += greaterCounts[p]
+		}
+
+		if !filtered {
+			filtered = true
+			if keptCount == 0 {
+				var zero T
+				return zero, false
+			}
+			n = index(keptCount)
+			if !keep(pivot) {
+				// the counts are relative to a value that was dropped.
+				values = Filter(values, keep)
+				continue
+			}
+		}
+
+		switch {
+		case n < lessCount:
+			values = Filter(values, func(v T) bool { return (keepAll || keep(v)) && less(v, pivot) })
+		case n < keptCount-greaterCount:
+			return pivot, true
+		default:
+			n -= keptCount - greaterCount
+			values = Filter(values, func(v T) bool { return (keepAll || keep(v)) && less(pivot, v) })
+		}
+	}
+
+	sorted := make([]T, 0, len(values))
+	for _, v := range values {
+		if filtered || keep(v) {
+			sorted = append(sorted, v)
+		}
+	}
+	if !filtered {
+		if len(sorted) == 0 {
+			var zero T
+			return zero, false
+		}
+		n = index(len(sorted))
+	}
+	sort.Slice(sorted, func(i, j int) bool { return less(sorted[i], sorted[j]) })
+	return sorted[n], true
+}
+
+// nthElementSortThreshold is the number of values below which NthElement
+// sorts the remaining values serially instead of partitioning them further.
+const nthElementSortThreshold = 1024
+
+// medianOfThree returns the median of a, b and c according to less.
+func medianOfThree[T any](a, b, c T, less func(a, b T) bool) T {
+	if less(b, a) {
+		a, b = b, a
+	}
+	if less(c, b) {
+		b = c
+		if less(b, a) {
+			b = a
+		}
+	}
+	return b
+}
+
+// Median returns the median of the values, i.e. the middle value of the
+// sorted values, or the mean of the two middle values if the number of values
+// is even.
+//
+// NaN values are ignored. If there are no other values, NaN is returned.
+//
+// The median is found using NthElement, without sorting the values.
+func Median[N Number](values []N) float64 {
+	isNumber := func(v N) bool { return v == v }
+	less := func(a, b N) bool { return a < b }
+
+	var count int
+	upper, ok := nthElement(values, isNumber, func(c int) int {
+		count = c
+		return c / 2
+	}, less)
+	if !ok {
+		return math.NaN()
+	}
+	if count%2 == 1 {
+		return float64(upper)
+	}
+	lower, _ := nthElement(values, isNumber, func(c int) int { return c/2 - 1 }, less)
+	return float64(lower) + (float64(upper)-float64(lower))/2
+}
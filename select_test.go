@@ -0,0 +1,72 @@
+package par_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/jussi-kalliokoski/par"
+)
+
+func TestNthElement(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range append(testLengths(1), 10000) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				rng := rand.New(rand.NewSource(int64(l)))
+				values := make([]int, l)
+				for i := range values {
+					values[i] = rng.Intn(l)
+				}
+				sorted := append([]int(nil), values...)
+				sort.Ints(sorted)
+
+				for _, n := range []int{0, l / 3, l / 2, l - 1} {
+					assertEquals(t, sorted[n], par.NthElement(values, n, less))
+				}
+			})
+		}
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.NthElement([]int{1, 2}, 2, less)
+		})
+		assertPanics(t, func() {
+			par.NthElement([]int{1, 2}, -1, less)
+		})
+	})
+}
+
+func TestMedian(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		t.Run("len 0", func(t *testing.T) {
+			assertEquals(t, true, math.IsNaN(par.Median([]int(nil))))
+		})
+
+		for _, l := range testLengths(1) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := rand.New(rand.NewSource(int64(l))).Perm(l)
+
+				assertEquals(t, float64(l-1)/2, par.Median(values))
+			})
+		}
+	})
+
+	t.Run("NaN", func(t *testing.T) {
+		values := make([]float64, 2001)
+		for i := range values {
+			values[i] = float64(i)
+		}
+		values[0], values[1000], values[2000] = math.NaN(), math.NaN(), math.NaN()
+
+		assertEquals(t, 1000.0, par.Median(values))
+		values[0], values[2000] = 0, 2000
+		values[1], values[1000], values[1999] = math.NaN(), math.NaN(), math.NaN()
+		assertEquals(t, 1000.0, par.Median(values))
+		assertEquals(t, true, math.IsNaN(par.Median([]float64{math.NaN()})))
+	})
+}
@@ -0,0 +1,260 @@
+package par
+
+import (
+	"sort"
+	"sync/atomic"
+)
+
+// Sort sorts the values in ascending order in place.
+//
+// Each partition is sorted in parallel, then the sorted partitions are merged
+// pairwise in rounds, as with MergeSorted, using a buffer of the size of
+// values, until the whole slice is sorted. The sort is not guaranteed to be
+// stable.
+func Sort[T Ordered](values []T) {
+	sortFunc(values, func(a, b T) bool { return a < b }, false)
+}
+
+// SortFunc sorts the values in place in the order defined by cmp, which
+// returns a negative number when a is before b, a positive number when a is
+// after b, and zero when their order does not matter, matching the signature
+// of slices.SortFunc.
+//
+// The implementation is the same as with Sort, and the sort is not
+// guaranteed to be stable.
+func SortFunc[T any](values []T, cmp func(a, b T) int) {
+	sortFunc(values, func(a, b T) bool { return cmp(a, b) < 0 }, false)
+}
+
+// SortStableFunc sorts the values in place in the order defined by cmp, as
+// with SortFunc, but keeping the original order of the values that cmp
+// considers equal.
+//
+// Each partition is sorted in parallel with a stable sort, then the sorted
+// partitions are merged pairwise in rounds with a stable merge, as with
+// MergeSorted, using a single auxiliary buffer of the size of values.
+func SortStableFunc[T any](values []T, cmp func(a, b T) int) {
+	sortFunc(values, func(a, b T) bool { return cmp(a, b) < 0 }, true)
+}
+
+// SortByKey sorts the values in place in ascending order of the keys
+// returned by the key function, keeping the original order of the values
+// with equal keys.
+//
+// The keys are extracted in a parallel pass before sorting, so the key
+// function is called exactly once per value instead of in every comparison,
+// which pays off when the key function is expensive. The keys are paired with
+// the values, the pairs are sorted as with SortStableFunc, and the values are
+// then copied back in parallel.
+func SortByKey[T any, K Ordered](values []T, key func(T) K) {
+	if len(values) < 2 {
+		return
+	}
+
+	pairs := Zip(Map(values, key), values)
+	sortFunc(pairs, func(a, b Pair[K, T]) bool { return a.First < b.First }, true)
+
+	partitions, partitionSize := parts(values)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		for i := start; i < end; i++ {
+			values[i] = pairs[i].Second
+		}
+	})
+}
+
+// ArgSort returns the permutation of the indices of values that sorts the
+// values in the order defined by cmp, without moving the values, so that
+// values[result[0]] is the first value in order, and so on. The indices of
+// values that cmp considers equal are in ascending order, so the result is
+// deterministic. The result can be used with ApplyPermutation to reorder
+// multiple aligned slices consistently.
+//
+// The indices are sorted as with SortStableFunc.
+func ArgSort[T any](values []T, cmp func(a, b T) int) []int {
+	indices := Generate(len(values), func(i int) int { return i })
+	sortFunc(indices, func(a, b int) bool { return cmp(values[a], values[b]) < 0 }, true)
+	return indices
+}
+
+// Rank returns the rank of each of the values in the order defined by cmp,
+// i.e. the number of values that are before it in order. Values that cmp
+// considers equal share the rank of the first of them in order, and the
+// following ranks are skipped, so the ranks of the values 10, 20, 20 and 30
+// are 0, 1, 1 and 3.
+//
+// The sorting permutation is computed with ArgSort, the starts of the groups
+// of equal values in it are propagated with a parallel Scan, and the ranks
+// are then written to the positions of the values in parallel.
+func Rank[T any](values []T, cmp func(a, b T) int) []int {
+	if len(values) == 0 {
+		return []int(nil)
+	}
+
+	order := ArgSort(values, cmp)
+	groupStarts := Scan(Generate(len(order), func(k int) int {
+		if k > 0 && cmp(values[order[k-1]], values[order[k]]) == 0 {
+			return 0
+		}
+		return k
+	}), maxInt)
+
+	result := make([]int, len(values))
+	partitions, partitionSize := parts(order)
+	forEachPart(partitions, partitionSize, len(order), func(p, start, end int) {
+		for k := start; k < end; k++ {
+			result[order[k]] = groupStarts[k]
+		}
+	})
+
+	return result
+}
+
+// IsSortedFunc returns a boolean indicating if the values are sorted in the
+// order defined by cmp.
+//
+// Each partition checks the pairs of adjacent values starting within it, so
+// the pairs spanning partition boundaries are checked as well. All of the
+// partitions terminate as soon as any of them finds a pair out of order, and
+// as such, cmp may not be called for every pair.
+func IsSortedFunc[T any](values []T, cmp func(a, b T) int) bool {
+	n := len(values) - 1
+	if n < 1 {
+		return true
+	}
+
+	partitions, partitionSize := partsOf(n)
+	var unsorted uint32
+	forEachPart(partitions, partitionSize, n, func(p, start, end int) {
+		for i := start; i < end; i++ {
+			if atomic.LoadUint32(&unsorted) != 0 {
+				return
+			}
+			if cmp(values[i+1], values[i]) < 0 {
+				atomic.StoreUint32(&unsorted, 1)
+				return
+			}
+		}
+	})
+
+	return unsorted == 0
+}
+
+// sortFunc sorts the values in place in the order defined by less, keeping
+// the original order of equal values if stable is true.
+func sortFunc[T any](values []T, less func(a, b T) bool, stable bool) {
+	if len(values) < 2 {
+		return
+	}
+
+	partitions, partitionSize := parts(values)
+	bounds := make([]int, partitions+1)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		if stable {
+			sort.Stable(sorter[T]{values[start:end], less})
+		} else {
+			sort.Sort(sorter[T]{values[start:end], less})
+		}
+		bounds[p+1] = end
+	})
+
+	sorted := mergeAllRuns(values, make([]T, len(values)), bounds, less)
+	if &sorted[0] != &values[0] {
+		copy(values, sorted)
+	}
+}
+
+// MergeSorted returns the items of the given slices, each sorted in the order
+// defined by cmp, merged into a single sorted slice. The merge is stable:
+// equal items keep the order of their slices and of their positions within
+// the slices.
+//
+// The slices are merged pairwise in rounds. Each pairwise merge divides its
+// output range into partitions of equal size, finds the corresponding split
+// points in the two slices with a binary search, and merges the disjoint
+// output ranges in parallel, so the work is divided evenly regardless of the
+// lengths of the individual slices.
+func MergeSorted[T any](slices [][]T, cmp func(a, b T) int) []T {
+	values := Concat(slices)
+	if len(values) == 0 {
+		return values
+	}
+
+	bounds := make([]int, 1, len(slices)+1)
+	for _, s := range slices {
+		bounds = append(bounds, bounds[len(bounds)-1]+len(s))
+	}
+	return mergeAllRuns(values, make([]T, len(values)), bounds, func(a, b T) bool {
+		return cmp(a, b) < 0
+	})
+}
+
+// mergeAllRuns merges the adjacent sorted runs of src delimited by bounds
+// pairwise in rounds, using dst of the same length as a buffer, and returns
+// the one of src and dst that holds the merged result.
+func mergeAllRuns[T any](src, dst []T, bounds []int, less func(a, b T) bool) []T {
+	for len(bounds) > 2 {
+		runs := len(bounds) - 1
+		next := []int{0}
+		for i := 0; i+1 < runs; i += 2 {
+			start, mid, end := bounds[i], bounds[i+1], bounds[i+2]
+			parallelMerge(dst[start:end], src[start:mid], src[mid:end], less)
+			next = append(next, end)
+		}
+		if runs%2 == 1 {
+			copy(dst[bounds[runs-1]:], src[bounds[runs-1]:])
+			next = append(next, bounds[runs])
+		}
+		src, dst, bounds = dst, src, next
+	}
+	return src
+}
+
+// parallelMerge merges the sorted runs a and b into dst as with mergeRuns,
+// dividing dst into partitions that are merged in parallel.
+func parallelMerge[T any](dst, a, b []T, less func(a, b T) bool) {
+	partitions, partitionSize := parts(dst)
+	forEachPart(partitions, partitionSize, len(dst), func(p, start, end int) {
+		i, j := coRank(start, a, b, less), coRank(end, a, b, less)
+		mergeRuns(dst[start:end], a[i:j], b[start-i:end-j], less)
+	})
+}
+
+// coRank returns the number of items of a among the first k items of the
+// stable merge of the sorted runs a and b.
+func coRank[T any](k int, a, b []T, less func(a, b T) bool) int {
+	lo := maxInt(0, k-len(b))
+	hi := minInt(k, len(a))
+	return lo + sort.Search(hi-lo, func(n int) bool {
+		i := lo + n
+		return less(b[k-i-1], a[i])
+	})
+}
+
+// mergeRuns merges the sorted runs a and b into dst, which must have the
+// combined length of a and b. The merge is stable: the items of a precede the
+// equal items of b.
+func mergeRuns[T any](dst, a, b []T, less func(a, b T) bool) {
+	i, j, k := 0, 0, 0
+	for i < len(a) && j < len(b) {
+		if less(b[j], a[i]) {
+			dst[k] = b[j]
+			j++
+		} else {
+			dst[k] = a[i]
+			i++
+		}
+		k++
+	}
+	k += copy(dst[k:], a[i:])
+	copy(dst[k:], b[j:])
+}
+
+// sorter adapts a slice and a less function to sort.Interface.
+type sorter[T any] struct {
+	values []T
+	less   func(a, b T) bool
+}
+
+func (s sorter[T]) Len() int           { return len(s.values) }
+func (s sorter[T]) Less(i, j int) bool { return s.less(s.values[i], s.values[j]) }
+func (s sorter[T]) Swap(i, j int)      { s.values[i], s.values[j] = s.values[j], s.values[i] }
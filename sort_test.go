@@ -0,0 +1,283 @@
+package par_test
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jussi-kalliokoski/par"
+)
+
+func TestSort(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range append(testLengths(0), 100000) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				rng := rand.New(rand.NewSource(int64(l)))
+				values := make([]int, l)
+				for i := range values {
+					values[i] = rng.Intn(l + 1)
+				}
+				expected := append([]int(nil), values...)
+				sort.Ints(expected)
+
+				par.Sort(values)
+
+				assertSliceEquals(t, expected, values)
+			})
+		}
+	})
+}
+
+func TestSortFunc(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				rng := rand.New(rand.NewSource(int64(l)))
+				values := make([]string, l)
+				for i := range values {
+					values[i] = fmt.Sprint(rng.Intn(l + 1))
+				}
+				expected := append([]string(nil), values...)
+				sort.Sort(sort.Reverse(sort.StringSlice(expected)))
+
+				par.SortFunc(values, func(a, b string) int {
+					return strings.Compare(b, a)
+				})
+
+				assertSliceEquals(t, expected, values)
+			})
+		}
+	})
+}
+
+func TestSortStableFunc(t *testing.T) {
+	type record struct {
+		Key   int
+		Index int
+	}
+
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range append(testLengths(0), 100000) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				rng := rand.New(rand.NewSource(int64(l)))
+				values := make([]record, l)
+				for i := range values {
+					values[i] = record{rng.Intn(10), i}
+				}
+				expected := append([]record(nil), values...)
+				sort.SliceStable(expected, func(i, j int) bool {
+					return expected[i].Key < expected[j].Key
+				})
+
+				par.SortStableFunc(values, func(a, b record) int {
+					return a.Key - b.Key
+				})
+
+				assertSliceEquals(t, expected, values)
+			})
+		}
+	})
+}
+
+func TestSortByKey(t *testing.T) {
+	type record struct {
+		Name  string
+		Index int
+	}
+
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				rng := rand.New(rand.NewSource(int64(l)))
+				values := make([]record, l)
+				for i := range values {
+					values[i] = record{fmt.Sprint(rng.Intn(10)), i}
+				}
+				expected := append([]record(nil), values...)
+				sort.SliceStable(expected, func(i, j int) bool {
+					return expected[i].Name < expected[j].Name
+				})
+
+				var calls int64
+				par.SortByKey(values, func(v record) string {
+					atomic.AddInt64(&calls, 1)
+					return v.Name
+				})
+
+				assertSliceEquals(t, expected, values)
+				if l > 1 {
+					assertEquals(t, int64(l), calls)
+				}
+			})
+		}
+	})
+}
+
+func TestMergeSorted(t *testing.T) {
+	type record struct {
+		Key   int
+		Slice int
+		Index int
+	}
+
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			for _, k := range []int{1, 2, 5} {
+				t.Run(fmt.Sprintf("len %d in %d", l, k), func(t *testing.T) {
+					rng := rand.New(rand.NewSource(int64(l)))
+					slices := make([][]record, k)
+					expected := []record(nil)
+					for i := 0; i < l; i++ {
+						s := rng.Intn(k)
+						v := record{rng.Intn(10), s, len(slices[s])}
+						slices[s] = append(slices[s], v)
+						expected = append(expected, v)
+					}
+					for _, s := range slices {
+						sort.SliceStable(s, func(i, j int) bool {
+							return s[i].Key < s[j].Key
+						})
+					}
+					sort.SliceStable(expected, func(i, j int) bool {
+						if expected[i].Key != expected[j].Key {
+							return expected[i].Key < expected[j].Key
+						}
+						return expected[i].Slice < expected[j].Slice
+					})
+
+					received := par.MergeSorted(slices, func(a, b record) int {
+						return a.Key - b.Key
+					})
+
+					assertEquals(t, len(expected), len(received))
+					for i := range expected {
+						assertEquals(t, expected[i].Key, received[i].Key)
+						assertEquals(t, expected[i].Slice, received[i].Slice)
+					}
+					for i := 1; i < len(received); i++ {
+						if received[i].Key == received[i-1].Key && received[i].Slice == received[i-1].Slice {
+							assertEquals(t, true, received[i].Index > received[i-1].Index)
+						}
+					}
+				})
+			}
+		}
+	})
+
+	t.Run("no slices", func(t *testing.T) {
+		assertEquals(t, 0, len(par.MergeSorted(nil, func(a, b int) int {
+			return a - b
+		})))
+	})
+}
+
+func TestIsSortedFunc(t *testing.T) {
+	cmp := func(a, b int) int {
+		return a - b
+	}
+
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				for i := range values {
+					values[i] = i / 3
+				}
+
+				assertEquals(t, true, par.IsSortedFunc(values, cmp))
+
+				for i := 1; i < l; i++ {
+					unsorted := append([]int(nil), values...)
+					unsorted[i] = -1
+					assertEquals(t, false, par.IsSortedFunc(unsorted, cmp))
+				}
+			})
+		}
+	})
+}
+
+func TestArgSort(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				rng := rand.New(rand.NewSource(int64(l)))
+				values := make([]int, l)
+				expected := make([]int, l)
+				for i := range values {
+					values[i] = rng.Intn(10)
+					expected[i] = i
+				}
+				sort.SliceStable(expected, func(i, j int) bool {
+					return values[expected[i]] < values[expected[j]]
+				})
+
+				received := par.ArgSort(values, func(a, b int) int {
+					return a - b
+				})
+
+				assertSliceEquals(t, expected, received)
+			})
+		}
+	})
+}
+
+func TestRank(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				rng := rand.New(rand.NewSource(int64(l)))
+				values := make([]int, l)
+				for i := range values {
+					values[i] = rng.Intn(10)
+				}
+				expected := make([]int, l)
+				for i := range values {
+					for _, v := range values {
+						if v < values[i] {
+							expected[i]++
+						}
+					}
+				}
+
+				received := par.Rank(values, func(a, b int) int {
+					return a - b
+				})
+
+				assertSliceEquals(t, expected, received)
+			})
+		}
+	})
+
+	t.Run("ties", func(t *testing.T) {
+		received := par.Rank([]int{20, 10, 30, 20}, func(a, b int) int {
+			return a - b
+		})
+
+		assertSliceEquals(t, []int{1, 0, 3, 1}, received)
+	})
+}
+
+func BenchmarkSort(b *testing.B) {
+	rand.Seed(1)
+	values := make([]int, 10000000)
+	for i := range values {
+		values[i] = rand.Int()
+	}
+	buf := make([]int, len(values))
+
+	b.Run("serial", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			copy(buf, values)
+			sort.Ints(buf)
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			copy(buf, values)
+			par.Sort(buf)
+		}
+	})
+}
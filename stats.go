@@ -0,0 +1,397 @@
+package par
+
+import (
+	"math"
+	"sort"
+)
+
+// Outliers returns the indices of the values whose score deviates from the
+// mean score by more than threshold standard deviations, i.e. whose z-score
+// exceeds threshold in absolute value.
+//
+// The operation is fused into two parallel passes: the first pass scores the
+// values and computes the mean and standard deviation of the scores, and the
+// second pass collects the indices of the outliers. The score function is
+// called exactly once per value.
+//
+// The implementation is deterministic, and the returned indices are in
+// ascending order.
+func Outliers[T any](values []T, score func(T) float64, threshold float64) []int {
+	if len(values) == 0 {
+		return []int(nil)
+	}
+
+	partitions, partitionSize := parts(values)
+	scores := make([]float64, len(values))
+	partials := make([]moments, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		var m moments
+		for i := start; i < end; i++ {
+			scores[i] = score(values[i])
+			m = m.add(scores[i])
+		}
+		partials[p] = m
+	})
+
+	m := mergeTree(partials, moments.merge)
+	limit := threshold * math.Sqrt(m.variance())
+	indices := make([][]int, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		for i := start; i < end; i++ {
+			if math.Abs(scores[i]-m.mean) > limit {
+				indices[p] = append(indices[p], i)
+			}
+		}
+	})
+
+	return Concat(indices)
+}
+
+// Mean returns the arithmetic mean of the values, or NaN if values is empty.
+//
+// The partial means of the partitions are merged in a numerically stable
+// way, so the result does not suffer from the loss of precision of summing
+// large numbers of values before dividing.
+func Mean[N Number](values []N) float64 {
+	m := momentsOf(values)
+	if m.n == 0 {
+		return math.NaN()
+	}
+	return m.mean
+}
+
+// Variance returns the population variance of the values, or NaN if values
+// is empty.
+//
+// Each partition computes its mean and sum of squared differences from the
+// mean with Welford's algorithm, and the partials are then merged with the
+// parallel algorithm by Chan et al., avoiding the catastrophic cancellation
+// of the naive sum of squares approach.
+func Variance[N Number](values []N) float64 {
+	return momentsOf(values).variance()
+}
+
+// StdDev returns the population standard deviation of the values, or NaN if
+// values is empty. See Variance for details.
+func StdDev[N Number](values []N) float64 {
+	return math.Sqrt(Variance(values))
+}
+
+// Stats holds summary statistics of a set of values, as returned by
+// Describe.
+type Stats[N Number] struct {
+	// Count is the number of values.
+	Count int
+	// Min is the smallest of the values.
+	Min N
+	// Max is the largest of the values.
+	Max N
+	// Mean is the arithmetic mean of the values.
+	Mean float64
+	// StdDev is the population standard deviation of the values.
+	StdDev float64
+}
+
+// Describe returns the summary statistics of the values in a single parallel
+// pass, instead of making a separate pass for each statistic.
+//
+// Each partition computes its extrema and moments, and the partials are then
+// merged as with Variance.
+//
+// If values is empty, Min and Max are zero, and Mean and StdDev are NaN.
+func Describe[N Number](values []N) Stats[N] {
+	if len(values) == 0 {
+		return Stats[N]{Mean: math.NaN(), StdDev: math.NaN()}
+	}
+
+	type partial struct {
+		min, max N
+		m        moments
+	}
+
+	partitions, partitionSize := parts(values)
+	partials := make([]partial, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		r := partial{min: values[start], max: values[start]}
+		for _, v := range values[start:end] {
+			if v < r.min {
+				r.min = v
+			}
+			if v > r.max {
+				r.max = v
+			}
+			r.m = r.m.add(float64(v))
+		}
+		partials[p] = r
+	})
+
+	r := mergeTree(partials, func(a, b partial) partial {
+		if b.min < a.min {
+			a.min = b.min
+		}
+		if b.max > a.max {
+			a.max = b.max
+		}
+		a.m = a.m.merge(b.m)
+		return a
+	})
+	return Stats[N]{
+		Count:  len(values),
+		Min:    r.min,
+		Max:    r.max,
+		Mean:   r.m.mean,
+		StdDev: math.Sqrt(r.m.variance()),
+	}
+}
+
+// momentsOf returns the moments of the values, computed in parallel.
+func momentsOf[N Number](values []N) moments {
+	if len(values) == 0 {
+		return moments{}
+	}
+
+	partitions, partitionSize := parts(values)
+	partials := make([]moments, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		var m moments
+		for _, v := range values[start:end] {
+			m = m.add(float64(v))
+		}
+		partials[p] = m
+	})
+
+	return mergeTree(partials, moments.merge)
+}
+
+// moments holds the count, the mean, and the sum of squared differences from
+// the mean of a set of values. Moments of disjoint sets can be merged with
+// the parallel algorithm by Chan et al., which is numerically stable unlike
+// summing the squares.
+type moments struct {
+	n    float64
+	mean float64
+	m2   float64
+}
+
+// add returns the moments with x added using Welford's algorithm.
+func (m moments) add(x float64) moments {
+	m.n++
+	delta := x - m.mean
+	m.mean += delta / m.n
+	m.m2 += delta * (x - m.mean)
+	return m
+}
+
+// merge returns the moments of the union of the sets of m and o.
+func (m moments) merge(o moments) moments {
+	if m.n == 0 {
+		return o
+	}
+	if o.n == 0 {
+		return m
+	}
+	n := m.n + o.n
+	delta := o.mean - m.mean
+	return moments{
+		n:    n,
+		mean: m.mean + delta*o.n/n,
+		m2:   m.m2 + o.m2 + delta*delta*m.n*o.n/n,
+	}
+}
+
+// variance returns the population variance of the values, or NaN if there
+// are no values.
+func (m moments) variance() float64 {
+	if m.n == 0 {
+		return math.NaN()
+	}
+	return m.m2 / m.n
+}
+
+// Histogram returns the number of values in each of nBuckets buckets, as
+// assigned by the bucketOf function, which returns the index of the bucket
+// each value belongs to.
+//
+// Each partition counts its values into its own bucket array, so no
+// synchronization is needed between partitions, then the arrays of the
+// partitions are summed in parallel over the buckets.
+//
+// Panics if nBuckets is less than 1, or if bucketOf returns an index outside
+// the range [0, nBuckets).
+func Histogram[T any](values []T, bucketOf func(T) int, nBuckets int) []int {
+	if nBuckets < 1 {
+		panic("cannot build a histogram with less than one bucket")
+	}
+
+	result := make([]int, nBuckets)
+	if len(values) == 0 {
+		return result
+	}
+
+	partitions, partitionSize := parts(values)
+	counts := make([]int, partitions*nBuckets)
+	outOfRange := make([]bool, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		c := counts[p*nBuckets : (p+1)*nBuckets]
+		for _, v := range values[start:end] {
+			b := bucketOf(v)
+			if uint(b) >= uint(nBuckets) {
+				outOfRange[p] = true
+				return
+			}
+			c[b]++
+		}
+	})
+	for _, o := range outOfRange {
+		if o {
+			panic("bucketOf returned an index out of range")
+		}
+	}
+
+	bucketPartitions, bucketPartitionSize := partsOf(nBuckets)
+	forEachPart(bucketPartitions, bucketPartitionSize, nBuckets, func(_, start, end int) {
+		for p := 0; p < partitions; p++ {
+			for b, c := range counts[p*nBuckets+start : p*nBuckets+end] {
+				result[start+b] += c
+			}
+		}
+	})
+
+	return result
+}
+
+// Covariance returns the population covariance of the paired values of a and
+// b, or NaN if they are empty.
+//
+// Each partition computes the co-moments of its pairs of values with
+// Welford's algorithm, and the partials are then merged with the parallel
+// algorithm by Chan et al., in the same way as with Variance.
+//
+// Panics if a and b are of different lengths.
+func Covariance(a, b []float64) float64 {
+	c := coMomentsOf(a, b)
+	if c.n == 0 {
+		return math.NaN()
+	}
+	return c.c / c.n
+}
+
+// Correlation returns the Pearson correlation coefficient of the paired
+// values of a and b, or NaN if they are empty or either of them is constant.
+//
+// The implementation is the same as with Covariance.
+//
+// Panics if a and b are of different lengths.
+func Correlation(a, b []float64) float64 {
+	c := coMomentsOf(a, b)
+	if c.n == 0 || c.a.m2 == 0 || c.b.m2 == 0 {
+		return math.NaN()
+	}
+	return c.c / math.Sqrt(c.a.m2*c.b.m2)
+}
+
+// coMoments holds the moments of two sets of paired values along with the
+// sum of the products of the differences from the means of the pairs.
+type coMoments struct {
+	n float64
+	a moments
+	b moments
+	c float64
+}
+
+// add returns the co-moments with the pair x, y added.
+func (m coMoments) add(x, y float64) coMoments {
+	dx := x - m.a.mean
+	m.n++
+	m.a = m.a.add(x)
+	m.b = m.b.add(y)
+	m.c += dx * (y - m.b.mean)
+	return m
+}
+
+// merge returns the co-moments of the union of the pairs of m and o.
+func (m coMoments) merge(o coMoments) coMoments {
+	if m.n == 0 {
+		return o
+	}
+	if o.n == 0 {
+		return m
+	}
+	n := m.n + o.n
+	dx := o.a.mean - m.a.mean
+	dy := o.b.mean - m.b.mean
+	return coMoments{
+		n: n,
+		a: m.a.merge(o.a),
+		b: m.b.merge(o.b),
+		c: m.c + o.c + dx*dy*m.n*o.n/n,
+	}
+}
+
+// coMomentsOf returns the co-moments of the paired values of a and b,
+// computed in parallel.
+func coMomentsOf(a, b []float64) coMoments {
+	if len(a) != len(b) {
+		panic("cannot pair values of different lengths")
+	}
+	if len(a) == 0 {
+		return coMoments{}
+	}
+
+	partitions, partitionSize := parts(a)
+	partials := make([]coMoments, partitions)
+	forEachPart(partitions, partitionSize, len(a), func(p, start, end int) {
+		var m coMoments
+		for i := start; i < end; i++ {
+			m = m.add(a[i], b[i])
+		}
+		partials[p] = m
+	})
+
+	return mergeTree(partials, coMoments.merge)
+}
+
+// Bucketize returns the index of the bin each of the values falls into, as
+// defined by the ascending boundaries: bin 0 holds the values less than the
+// first boundary, bin i holds the values from boundary i-1 up to but not
+// including boundary i, and bin len(boundaries) holds the values from the
+// last boundary up. The bin of each value is found with a binary search.
+//
+// Panics if the boundaries are not in ascending order.
+func Bucketize[T Ordered](values []T, boundaries []T) []int {
+	checkBoundaries(boundaries)
+	return Map(values, func(v T) int {
+		return binOf(v, boundaries)
+	})
+}
+
+// BucketCounts returns the number of values in each of the bins defined by
+// the ascending boundaries, as assigned by Bucketize, without allocating the
+// bin index of each value.
+//
+// The implementation is the same as with Histogram.
+//
+// Panics if the boundaries are not in ascending order.
+func BucketCounts[T Ordered](values []T, boundaries []T) []int {
+	checkBoundaries(boundaries)
+	return Histogram(values, func(v T) int {
+		return binOf(v, boundaries)
+	}, len(boundaries)+1)
+}
+
+// checkBoundaries panics if the boundaries are not in ascending order.
+func checkBoundaries[T Ordered](boundaries []T) {
+	for i := 1; i < len(boundaries); i++ {
+		if boundaries[i] < boundaries[i-1] {
+			panic("cannot bucketize with boundaries that are not in ascending order")
+		}
+	}
+}
+
+// binOf returns the index of the bin v falls into, i.e. the number of
+// boundaries less than or equal to v.
+func binOf[T Ordered](v T, boundaries []T) int {
+	return sort.Search(len(boundaries), func(i int) bool {
+		return boundaries[i] > v
+	})
+}
@@ -0,0 +1,276 @@
+package par_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/jussi-kalliokoski/par"
+)
+
+func TestOutliers(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]float64, l)
+				for i := range values {
+					values[i] = float64(i % 10)
+					if i%37 == 36 {
+						values[i] = 1000
+					}
+				}
+				var mean, variance float64
+				for _, v := range values {
+					mean += v / float64(l)
+				}
+				for _, v := range values {
+					variance += (v - mean) * (v - mean) / float64(l)
+				}
+				expected := []int(nil)
+				for i, v := range values {
+					if math.Abs(v-mean) > 2*math.Sqrt(variance) {
+						expected = append(expected, i)
+					}
+				}
+
+				received := par.Outliers(values, func(v float64) float64 {
+					return v
+				}, 2)
+
+				assertSliceEquals(t, expected, received)
+			})
+		}
+	})
+}
+
+func TestMean(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		t.Run("len 0", func(t *testing.T) {
+			assertEquals(t, true, math.IsNaN(par.Mean([]float64(nil))))
+		})
+
+		for _, l := range testLengths(1) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				var sum int
+				for i := range values {
+					values[i] = i%13 - 4
+					sum += values[i]
+				}
+
+				assertApprox(t, float64(sum)/float64(l), par.Mean(values), 1e-9)
+			})
+		}
+	})
+
+	t.Run("large offset", func(t *testing.T) {
+		values := make([]float64, 1000)
+		for i := range values {
+			values[i] = 1e9 + float64(i%2)
+		}
+
+		assertApprox(t, 1e9+0.5, par.Mean(values), 1e-6)
+	})
+}
+
+func TestVariance(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		t.Run("len 0", func(t *testing.T) {
+			assertEquals(t, true, math.IsNaN(par.Variance([]float64(nil))))
+		})
+
+		for _, l := range testLengths(1) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]float64, l)
+				var mean float64
+				for i := range values {
+					values[i] = float64(i%13) * 0.5
+					mean += values[i] / float64(l)
+				}
+				var expected float64
+				for _, v := range values {
+					expected += (v - mean) * (v - mean) / float64(l)
+				}
+
+				assertApprox(t, expected, par.Variance(values), 1e-9)
+				assertApprox(t, math.Sqrt(expected), par.StdDev(values), 1e-9)
+			})
+		}
+	})
+
+	t.Run("large offset", func(t *testing.T) {
+		values := make([]float64, 1000)
+		for i := range values {
+			values[i] = 1e9 + float64(i%2)
+		}
+
+		assertApprox(t, 0.25, par.Variance(values), 1e-6)
+	})
+}
+
+func TestDescribe(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		t.Run("len 0", func(t *testing.T) {
+			received := par.Describe([]int(nil))
+
+			assertEquals(t, 0, received.Count)
+			assertEquals(t, 0, received.Min)
+			assertEquals(t, 0, received.Max)
+			assertEquals(t, true, math.IsNaN(received.Mean))
+			assertEquals(t, true, math.IsNaN(received.StdDev))
+		})
+
+		for _, l := range testLengths(1) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				for i := range values {
+					values[i] = (i*7919)%101 - 50
+				}
+
+				received := par.Describe(values)
+
+				assertEquals(t, l, received.Count)
+				assertEquals(t, par.Min(values), received.Min)
+				assertEquals(t, par.Max(values), received.Max)
+				assertApprox(t, par.Mean(values), received.Mean, 1e-9)
+				assertApprox(t, par.StdDev(values), received.StdDev, 1e-9)
+			})
+		}
+	})
+}
+
+func TestHistogram(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				expected := make([]int, 10)
+				for i := range values {
+					values[i] = (i * 7919) % 100
+					expected[values[i]/10]++
+				}
+
+				received := par.Histogram(values, func(v int) int {
+					return v / 10
+				}, 10)
+
+				assertSliceEquals(t, expected, received)
+			})
+		}
+	})
+
+	t.Run("invalid bucket count", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.Histogram([]int{1, 2}, func(v int) int { return 0 }, 0)
+		})
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.Histogram([]int{1, 2}, func(v int) int { return v }, 2)
+		})
+	})
+}
+
+func TestCovariance(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		t.Run("len 0", func(t *testing.T) {
+			assertEquals(t, true, math.IsNaN(par.Covariance(nil, nil)))
+		})
+
+		for _, l := range testLengths(1) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				a := make([]float64, l)
+				b := make([]float64, l)
+				var meanA, meanB float64
+				for i := range a {
+					a[i] = float64(i%13) + 1e6
+					b[i] = float64(i%7) * 0.5
+					meanA += a[i] / float64(l)
+					meanB += b[i] / float64(l)
+				}
+				var expected float64
+				for i := range a {
+					expected += (a[i] - meanA) * (b[i] - meanB) / float64(l)
+				}
+
+				assertApprox(t, expected, par.Covariance(a, b), 1e-6)
+			})
+		}
+	})
+
+	t.Run("different lengths", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.Covariance([]float64{1, 2}, []float64{1})
+		})
+	})
+}
+
+func TestCorrelation(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		t.Run("len 0", func(t *testing.T) {
+			assertEquals(t, true, math.IsNaN(par.Correlation(nil, nil)))
+		})
+
+		for _, l := range testLengths(2) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				a := make([]float64, l)
+				b := make([]float64, l)
+				c := make([]float64, l)
+				for i := range a {
+					a[i] = float64(i)
+					b[i] = 3*float64(i) + 1
+					c[i] = -float64(i)
+				}
+
+				assertApprox(t, 1, par.Correlation(a, b), 1e-9)
+				assertApprox(t, -1, par.Correlation(a, c), 1e-9)
+			})
+		}
+	})
+
+	t.Run("constant", func(t *testing.T) {
+		assertEquals(t, true, math.IsNaN(par.Correlation([]float64{1, 1}, []float64{1, 2})))
+	})
+
+	t.Run("different lengths", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.Correlation([]float64{1, 2}, []float64{1})
+		})
+	})
+}
+
+func TestBucketize(t *testing.T) {
+	boundaries := []int{10, 20, 20, 50}
+
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				expected := make([]int, l)
+				expectedCounts := make([]int, len(boundaries)+1)
+				for i := range values {
+					values[i] = i % 60
+					for _, b := range boundaries {
+						if values[i] >= b {
+							expected[i]++
+						}
+					}
+					expectedCounts[expected[i]]++
+				}
+
+				assertSliceEquals(t, expected, par.Bucketize(values, boundaries))
+				assertSliceEquals(t, expectedCounts, par.BucketCounts(values, boundaries))
+			})
+		}
+	})
+
+	t.Run("unordered boundaries", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.Bucketize([]int{1, 2}, []int{2, 1})
+		})
+		assertPanics(t, func() {
+			par.BucketCounts([]int{1, 2}, []int{2, 1})
+		})
+	})
+}
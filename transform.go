@@ -0,0 +1,282 @@
+package par
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// ApplyWhere replaces every item in values for which the predicate returns
+// true with the result of applying the update function on it, and returns the
+// number of updated items.
+//
+// The values are updated in place, with each partition updating its own
+// items, so both the predicate and the update function are called exactly
+// once per item in a single traversal.
+func ApplyWhere[T any](values []T, predicate func(T) bool, update func(T) T) int {
+	if len(values) == 0 {
+		return 0
+	}
+
+	partitions, partitionSize := parts(values)
+	counts := make([]int, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		var count int
+		for i := start; i < end; i++ {
+			if predicate(values[i]) {
+				values[i] = update(values[i])
+				count++
+			}
+		}
+		counts[p] = count
+	})
+
+	var total int
+	for _, c := range counts {
+		total += c
+	}
+	return total
+}
+
+// StridedMap returns a slice of type Out by applying the transform function on
+// every stride-th item in values, starting from the item at offset.
+//
+// This allows processing interleaved data, e.g. a single channel of
+// interleaved audio, without first copying the items out of values.
+//
+// The implementation is deterministic, and the returned slice maintains the
+// order of the original values.
+//
+// Panics if offset is negative or stride is less than 1.
+func StridedMap[In, Out any](values []In, offset, stride int, transform func(In) Out) []Out {
+	n := stridedLen(len(values), offset, stride)
+	if n == 0 {
+		return []Out(nil)
+	}
+
+	partitions, partitionSize := partsOf(n)
+	result := make([]Out, n)
+	forEachPart(partitions, partitionSize, n, func(p, start, end int) {
+		for i := start; i < end; i++ {
+			result[i] = transform(values[offset+i*stride])
+		}
+	})
+
+	return result
+}
+
+// StridedMapInPlace replaces every stride-th item in values, starting from
+// the item at offset, with the result of applying the transform function on
+// it.
+//
+// Panics if offset is negative or stride is less than 1.
+func StridedMapInPlace[T any](values []T, offset, stride int, transform func(T) T) {
+	n := stridedLen(len(values), offset, stride)
+	if n == 0 {
+		return
+	}
+
+	partitions, partitionSize := partsOf(n)
+	forEachPart(partitions, partitionSize, n, func(p, start, end int) {
+		for i := start; i < end; i++ {
+			j := offset + i*stride
+			values[j] = transform(values[j])
+		}
+	})
+}
+
+// stridedLen returns the number of items in a slice of length n at the
+// given offset and stride.
+func stridedLen(n, offset, stride int) int {
+	if offset < 0 {
+		panic("cannot stride from a negative offset")
+	}
+	if stride < 1 {
+		panic("cannot stride with a stride less than one")
+	}
+	if offset >= n {
+		return 0
+	}
+	return (n - offset + stride - 1) / stride
+}
+
+// MapPartitions returns a slice of type Out by applying the transform function
+// on every partition of values, and concatenating the results of each
+// partition in order.
+//
+// The transform function receives a view of an entire partition, which allows
+// amortizing per-item setup, such as buffers or encoders, across all the
+// items in the partition. The transform function may return any number of
+// results for a partition.
+//
+// The implementation is deterministic, and the returned slice maintains the
+// order of the partitions.
+func MapPartitions[In, Out any](values []In, transform func([]In) []Out) []Out {
+	if len(values) == 0 {
+		return []Out(nil)
+	}
+
+	partitions, partitionSize := parts(values)
+	results := make([][]Out, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		results[p] = transform(values[start:end:end])
+	})
+
+	return Concat(results)
+}
+
+// MapBatches returns a slice of type Out by applying the transform function on
+// consecutive batches of values of the given size, and concatenating the
+// results of each batch in order.
+//
+// Unlike with MapPartitions, the batches are independent of the partitioning:
+// the batches are processed concurrently by as many workers as there are
+// partitions, with each worker picking up the next unprocessed batch.
+//
+// If the transform function returns an error, no more batches are processed,
+// and the error of the earliest batch that failed is returned along with a
+// nil slice. MapBatches always waits for all the started batches to finish
+// before returning.
+//
+// Panics if batchSize is less than 1.
+func MapBatches[In, Out any](values []In, batchSize int, transform func([]In) ([]Out, error)) ([]Out, error) {
+	batches := Chunk(values, batchSize)
+	if len(batches) == 0 {
+		return []Out(nil), nil
+	}
+
+	workers, _ := parts(batches)
+	results := make([][]Out, len(batches))
+	errs := make([]error, len(batches))
+	var next int64
+	var failed int32
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for atomic.LoadInt32(&failed) == 0 {
+				b := int(atomic.AddInt64(&next, 1) - 1)
+				if b >= len(batches) {
+					return
+				}
+				results[b], errs[b] = transform(batches[b])
+				if errs[b] != nil {
+					atomic.StoreInt32(&failed, 1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return []Out(nil), err
+		}
+	}
+	return Concat(results), nil
+}
+
+// Windowed returns a slice of type Out by applying the transform function on
+// every sliding window of windowSize consecutive items in values, i.e.
+// len(values)-windowSize+1 windows. If values is shorter than windowSize,
+// the result is empty.
+//
+// The windows passed to transform are views into values, and they do not
+// share capacity, so appending to a window does not overwrite values.
+//
+// The implementation is deterministic, and the returned slice maintains the
+// order of the windows.
+//
+// Panics if windowSize is less than 1.
+func Windowed[T, Out any](values []T, windowSize int, transform func([]T) Out) []Out {
+	if windowSize < 1 {
+		panic("cannot window with a size less than one")
+	}
+	n := len(values) - windowSize + 1
+	if n <= 0 {
+		return []Out(nil)
+	}
+
+	partitions, partitionSize := partsOf(n)
+	result := make([]Out, n)
+	forEachPart(partitions, partitionSize, n, func(p, start, end int) {
+		for i := start; i < end; i++ {
+			result[i] = transform(values[i : i+windowSize : i+windowSize])
+		}
+	})
+
+	return result
+}
+
+// Pairwise returns a slice of type Out by applying the transform function on
+// every pair of consecutive items in values, i.e. len(values)-1 pairs.
+//
+// The implementation is deterministic, and the returned slice maintains the
+// order of the pairs.
+func Pairwise[T, Out any](values []T, transform func(prev, cur T) Out) []Out {
+	n := len(values) - 1
+	if n <= 0 {
+		return []Out(nil)
+	}
+
+	partitions, partitionSize := partsOf(n)
+	result := make([]Out, n)
+	forEachPart(partitions, partitionSize, n, func(p, start, end int) {
+		for i := start; i < end; i++ {
+			result[i] = transform(values[i], values[i+1])
+		}
+	})
+
+	return result
+}
+
+// MaskedMap returns a copy of values where every item for which the item at
+// the same index in mask is true is replaced with the result of applying the
+// transform function on it.
+//
+// The implementation is deterministic, and the returned slice maintains the
+// order of the original values.
+//
+// Panics if values and mask are of different lengths.
+func MaskedMap[T any](values []T, mask []bool, transform func(T) T) []T {
+	if len(values) != len(mask) {
+		panic("cannot map values with a mask of a different length")
+	}
+	if len(values) == 0 {
+		return []T(nil)
+	}
+
+	partitions, partitionSize := parts(values)
+	result := make([]T, len(values))
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		for i := start; i < end; i++ {
+			if mask[i] {
+				result[i] = transform(values[i])
+			} else {
+				result[i] = values[i]
+			}
+		}
+	})
+
+	return result
+}
+
+// ReplaceIf returns a copy of values where every item for which the predicate
+// returns true is replaced with replacement.
+//
+// The implementation is deterministic, and the returned slice maintains the
+// order of the original values.
+func ReplaceIf[T any](values []T, predicate func(T) bool, replacement T) []T {
+	return Map(values, func(v T) T {
+		if predicate(v) {
+			return replacement
+		}
+		return v
+	})
+}
+
+// ReplaceIfInPlace replaces every item in values for which the predicate
+// returns true with replacement, and returns the number of replaced items.
+func ReplaceIfInPlace[T any](values []T, predicate func(T) bool, replacement T) int {
+	return ApplyWhere(values, predicate, func(T) T { return replacement })
+}
@@ -0,0 +1,340 @@
+package par_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jussi-kalliokoski/par"
+)
+
+func TestApplyWhere(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				expected := make([]int, l)
+				var expectedCount int
+				for i := range values {
+					values[i] = i
+					expected[i] = i
+					if i%3 == 0 {
+						expected[i] = -i
+						expectedCount++
+					}
+				}
+
+				received := par.ApplyWhere(values, func(v int) bool {
+					return v%3 == 0
+				}, func(v int) int {
+					return -v
+				})
+
+				assertEquals(t, expectedCount, received)
+				assertSliceEquals(t, expected, values)
+			})
+		}
+	})
+}
+
+func TestStridedMap(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			for _, stride := range []int{1, 2, 5} {
+				for offset := 0; offset < stride+1; offset++ {
+					t.Run(fmt.Sprintf("len %d offset %d stride %d", l, offset, stride), func(t *testing.T) {
+						values := make([]int, l)
+						expected := []int(nil)
+						for i := range values {
+							values[i] = i
+							if i >= offset && (i-offset)%stride == 0 {
+								expected = append(expected, i*2)
+							}
+						}
+
+						received := par.StridedMap(values, offset, stride, func(v int) int {
+							return v * 2
+						})
+
+						assertSliceEquals(t, expected, received)
+					})
+				}
+			}
+		}
+	})
+
+	t.Run("invalid stride", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.StridedMap([]int{1, 2}, 0, 0, func(v int) int { return v })
+		})
+	})
+
+	t.Run("negative offset", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.StridedMap([]int{1, 2}, -1, 1, func(v int) int { return v })
+		})
+	})
+}
+
+func TestStridedMapInPlace(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			for _, stride := range []int{1, 2, 5} {
+				for offset := 0; offset < stride+1; offset++ {
+					t.Run(fmt.Sprintf("len %d offset %d stride %d", l, offset, stride), func(t *testing.T) {
+						values := make([]int, l)
+						expected := make([]int, l)
+						for i := range values {
+							values[i] = i
+							expected[i] = i
+							if i >= offset && (i-offset)%stride == 0 {
+								expected[i] = i * 2
+							}
+						}
+
+						par.StridedMapInPlace(values, offset, stride, func(v int) int {
+							return v * 2
+						})
+
+						assertSliceEquals(t, expected, values)
+					})
+				}
+			}
+		}
+	})
+}
+
+func TestMapPartitions(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				expected := []int(nil)
+				for i := range values {
+					values[i] = i
+					for r := 0; r < i%3; r++ {
+						expected = append(expected, i*2)
+					}
+				}
+
+				received := par.MapPartitions(values, func(chunk []int) []int {
+					out := []int(nil)
+					for _, v := range chunk {
+						for r := 0; r < v%3; r++ {
+							out = append(out, v*2)
+						}
+					}
+					return out
+				})
+
+				assertSliceEquals(t, expected, received)
+			})
+		}
+	})
+}
+
+func TestMapBatches(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			for _, size := range []int{1, 7, 100} {
+				t.Run(fmt.Sprintf("len %d by %d", l, size), func(t *testing.T) {
+					values := make([]int, l)
+					expected := make([]int, l)
+					for i := range values {
+						values[i] = i
+						expected[i] = i * 2
+					}
+
+					received, err := par.MapBatches(values, size, func(batch []int) ([]int, error) {
+						if len(batch) > size {
+							t.Errorf("expected batch of at most %d values, got %d", size, len(batch))
+						}
+						out := make([]int, len(batch))
+						for i, v := range batch {
+							out[i] = v * 2
+						}
+						return out, nil
+					})
+
+					if err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+					assertSliceEquals(t, expected, received)
+				})
+			}
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		values := make([]int, 1000)
+		for i := range values {
+			values[i] = i
+		}
+		expected := errors.New("batch failed")
+
+		received, err := par.MapBatches(values, 10, func(batch []int) ([]int, error) {
+			if batch[0] >= 500 {
+				return nil, expected
+			}
+			return batch, nil
+		})
+
+		if err != expected {
+			t.Fatalf("expected error `%v`, got `%v`", expected, err)
+		}
+		assertEquals(t, 0, len(received))
+	})
+
+	t.Run("invalid batch size", func(t *testing.T) {
+		assertPanics(t, func() {
+			_, _ = par.MapBatches([]int{1}, 0, func(batch []int) ([]int, error) {
+				return batch, nil
+			})
+		})
+	})
+}
+
+func TestWindowed(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			for _, size := range []int{1, 3, 10} {
+				t.Run(fmt.Sprintf("len %d window %d", l, size), func(t *testing.T) {
+					values := make([]int, l)
+					for i := range values {
+						values[i] = i
+					}
+					expected := []int(nil)
+					for i := 0; i+size <= l; i++ {
+						var sum int
+						for _, v := range values[i : i+size] {
+							sum += v
+						}
+						expected = append(expected, sum)
+					}
+
+					received := par.Windowed(values, size, func(window []int) int {
+						var sum int
+						for _, v := range window {
+							sum += v
+						}
+						return sum
+					})
+
+					assertSliceEquals(t, expected, received)
+				})
+			}
+		}
+	})
+
+	t.Run("invalid window size", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.Windowed([]int{1, 2}, 0, func(window []int) int { return 0 })
+		})
+	})
+}
+
+func TestPairwise(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				for i := range values {
+					values[i] = i * i
+				}
+				expected := []int(nil)
+				for i := 1; i < l; i++ {
+					expected = append(expected, values[i]-values[i-1])
+				}
+
+				received := par.Pairwise(values, func(prev, cur int) int {
+					return cur - prev
+				})
+
+				assertSliceEquals(t, expected, received)
+			})
+		}
+	})
+}
+
+func TestMaskedMap(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				mask := make([]bool, l)
+				expected := make([]int, l)
+				for i := range values {
+					values[i] = i
+					mask[i] = i%3 == 1
+					expected[i] = i
+					if mask[i] {
+						expected[i] = -i
+					}
+				}
+
+				received := par.MaskedMap(values, mask, func(v int) int {
+					return -v
+				})
+
+				assertSliceEquals(t, expected, received)
+			})
+		}
+	})
+
+	t.Run("different lengths", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.MaskedMap([]int{1, 2}, []bool{true}, func(v int) int { return v })
+		})
+	})
+}
+
+func TestReplaceIf(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				expected := make([]int, l)
+				for i := range values {
+					values[i] = i
+					expected[i] = i
+					if i%4 == 0 {
+						expected[i] = -1
+					}
+				}
+
+				received := par.ReplaceIf(values, func(v int) bool {
+					return v%4 == 0
+				}, -1)
+
+				assertSliceEquals(t, expected, received)
+			})
+		}
+	})
+}
+
+func TestReplaceIfInPlace(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				expected := make([]int, l)
+				var expectedCount int
+				for i := range values {
+					values[i] = i
+					expected[i] = i
+					if i%4 == 0 {
+						expected[i] = -1
+						expectedCount++
+					}
+				}
+
+				received := par.ReplaceIfInPlace(values, func(v int) bool {
+					return v%4 == 0
+				}, -1)
+
+				assertEquals(t, expectedCount, received)
+				assertSliceEquals(t, expected, values)
+			})
+		}
+	})
+}
@@ -0,0 +1,101 @@
+package par
+
+import (
+	"sort"
+	"strconv"
+)
+
+// CheckAll evaluates a set of named checks over the values in a single pass,
+// and returns the indices of the values that failed each check, keyed by the
+// name of the check.
+//
+// Only the checks with at least one failing value are included in the
+// result, so an empty result means that all the values passed all the
+// checks. The indices for each check are in ascending order.
+func CheckAll[T any](values []T, checks map[string]func(T) bool) map[string][]int {
+	names := make([]string, 0, len(checks))
+	for name := range checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	predicates := make([]func(T) bool, len(names))
+	for c, name := range names {
+		predicates[c] = checks[name]
+	}
+
+	result := make(map[string][]int)
+	if len(values) == 0 || len(names) == 0 {
+		return result
+	}
+
+	partitions, partitionSize := parts(values)
+	failures := make([][][]int, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		f := make([][]int, len(predicates))
+		for i := start; i < end; i++ {
+			for c, predicate := range predicates {
+				if !predicate(values[i]) {
+					f[c] = append(f[c], i)
+				}
+			}
+		}
+		failures[p] = f
+	})
+
+	for c, name := range names {
+		var count int
+		for p := range failures {
+			count += len(failures[p][c])
+		}
+		if count == 0 {
+			continue
+		}
+		indices := make([]int, 0, count)
+		for p := range failures {
+			indices = append(indices, failures[p][c]...)
+		}
+		result[name] = indices
+	}
+	return result
+}
+
+// IndexedError is an error associated with the index of the value that
+// caused it.
+type IndexedError struct {
+	Index int
+	Err   error
+}
+
+// Error implements the error interface.
+func (e IndexedError) Error() string {
+	return "index " + strconv.Itoa(e.Index) + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e IndexedError) Unwrap() error {
+	return e.Err
+}
+
+// Validate runs the check on every value and returns all the errors returned
+// by the check along with the indices of the values that failed, in ascending
+// order of the indices. An empty result means that all the values passed.
+//
+// Unlike with fail-fast approaches, the check is always called for every
+// value.
+func Validate[T any](values []T, check func(T) error) []IndexedError {
+	if len(values) == 0 {
+		return []IndexedError(nil)
+	}
+
+	partitions, partitionSize := parts(values)
+	failures := make([][]IndexedError, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		for i := start; i < end; i++ {
+			if err := check(values[i]); err != nil {
+				failures[p] = append(failures[p], IndexedError{i, err})
+			}
+		}
+	})
+
+	return Concat(failures)
+}
@@ -0,0 +1,81 @@
+package par_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jussi-kalliokoski/par"
+)
+
+func TestCheckAll(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				expectedOdd := []int(nil)
+				expectedSmall := []int(nil)
+				for i := range values {
+					values[i] = i
+					if i%2 != 0 {
+						expectedOdd = append(expectedOdd, i)
+					}
+					if i >= 100 {
+						expectedSmall = append(expectedSmall, i)
+					}
+				}
+
+				received := par.CheckAll(values, map[string]func(int) bool{
+					"even":     func(v int) bool { return v%2 == 0 },
+					"small":    func(v int) bool { return v < 100 },
+					"positive": func(v int) bool { return v >= 0 },
+				})
+
+				_, ok := received["positive"]
+				assertEquals(t, false, ok)
+				assertSliceEquals(t, expectedOdd, received["even"])
+				assertSliceEquals(t, expectedSmall, received["small"])
+			})
+		}
+	})
+}
+
+func TestValidate(t *testing.T) {
+	errNegative := errors.New("negative")
+
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				expected := []par.IndexedError(nil)
+				for i := range values {
+					values[i] = i
+					if i%6 == 5 {
+						values[i] = -i
+						expected = append(expected, par.IndexedError{Index: i, Err: errNegative})
+					}
+				}
+
+				received := par.Validate(values, func(v int) error {
+					if v < 0 {
+						return errNegative
+					}
+					return nil
+				})
+
+				assertEquals(t, len(expected), len(received))
+				for i := range expected {
+					assertEquals(t, expected[i].Index, received[i].Index)
+					assertError(t, expected[i].Err, received[i].Err)
+				}
+			})
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		err := par.IndexedError{Index: 3, Err: errNegative}
+
+		assertEquals(t, "index 3: negative", err.Error())
+		assertError(t, errNegative, err)
+	})
+}
@@ -0,0 +1,412 @@
+package par
+
+import (
+	"math"
+)
+
+// Dot returns the dot product of a and b, i.e. the sum of the products of the
+// items at the same index.
+//
+// Each partition sums its products in order, and the partition sums are then
+// added in the order of the partitions, so for a given number of partitions
+// the result is deterministic, even for floating-point numbers.
+//
+// Panics if a and b are of different lengths.
+func Dot[N Number](a, b []N) N {
+	if len(a) != len(b) {
+		panic("cannot multiply slices of different lengths")
+	}
+	if len(a) == 0 {
+		return 0
+	}
+
+	partitions, partitionSize := parts(a)
+	sums := make([]N, partitions)
+	forEachPart(partitions, partitionSize, len(a), func(p, start, end int) {
+		a, b := a[start:end], b[start:end]
+		var sum N
+		for i := range a {
+			sum += a[i] * b[i]
+		}
+		sums[p] = sum
+	})
+
+	var total N
+	for _, s := range sums {
+		total += s
+	}
+	return total
+}
+
+// Add returns a slice where each item is the sum of the items at the same
+// index in a and b.
+//
+// Panics if a and b are of different lengths.
+func Add[N Number](a, b []N) []N {
+	return elementwise(a, b, func(dst, a, b []N) {
+		for i := range dst {
+			dst[i] = a[i] + b[i]
+		}
+	})
+}
+
+// Sub returns a slice where each item is the difference of the items at the
+// same index in a and b.
+//
+// Panics if a and b are of different lengths.
+func Sub[N Number](a, b []N) []N {
+	return elementwise(a, b, func(dst, a, b []N) {
+		for i := range dst {
+			dst[i] = a[i] - b[i]
+		}
+	})
+}
+
+// Mul returns a slice where each item is the product of the items at the
+// same index in a and b.
+//
+// Panics if a and b are of different lengths.
+func Mul[N Number](a, b []N) []N {
+	return elementwise(a, b, func(dst, a, b []N) {
+		for i := range dst {
+			dst[i] = a[i] * b[i]
+		}
+	})
+}
+
+// elementwise returns a slice of the length of a and b, filled in parallel by
+// calling op once per partition with the corresponding ranges of the result,
+// a and b, so no function is called per item.
+func elementwise[N Number](a, b []N, op func(dst, a, b []N)) []N {
+	if len(a) != len(b) {
+		panic("cannot combine slices of different lengths")
+	}
+	if len(a) == 0 {
+		return []N(nil)
+	}
+
+	partitions, partitionSize := parts(a)
+	result := make([]N, len(a))
+	forEachPart(partitions, partitionSize, len(a), func(p, start, end int) {
+		op(result[start:end], a[start:end], b[start:end])
+	})
+
+	return result
+}
+
+// Scale multiplies each item in dst by s in place.
+//
+// Each partition scales its items in a loop unrolled by four.
+func Scale[N Number](dst []N, s N) {
+	if len(dst) == 0 {
+		return
+	}
+
+	partitions, partitionSize := parts(dst)
+	forEachPart(partitions, partitionSize, len(dst), func(p, start, end int) {
+		dst := dst[start:end]
+		i := 0
+		for ; i+4 <= len(dst); i += 4 {
+			dst[i] *= s
+			dst[i+1] *= s
+			dst[i+2] *= s
+			dst[i+3] *= s
+		}
+		for ; i < len(dst); i++ {
+			dst[i] *= s
+		}
+	})
+}
+
+// AXPY adds a times each item in x to the item at the same index in y in
+// place, i.e. y[i] += a * x[i].
+//
+// Each partition updates its items in a loop unrolled by four.
+//
+// Panics if x and y are of different lengths.
+func AXPY[N Number](y []N, a N, x []N) {
+	if len(x) != len(y) {
+		panic("cannot combine slices of different lengths")
+	}
+	if len(y) == 0 {
+		return
+	}
+
+	partitions, partitionSize := parts(y)
+	forEachPart(partitions, partitionSize, len(y), func(p, start, end int) {
+		y, x := y[start:end], x[start:end]
+		i := 0
+		for ; i+4 <= len(y); i += 4 {
+			y[i] += a * x[i]
+			y[i+1] += a * x[i+1]
+			y[i+2] += a * x[i+2]
+			y[i+3] += a * x[i+3]
+		}
+		for ; i < len(y); i++ {
+			y[i] += a * x[i]
+		}
+	})
+}
+
+// Norm returns the Euclidean norm of the values, i.e. the square root of the
+// sum of their squares.
+//
+// Each partition sums its squares in a loop unrolled by four, using separate
+// accumulators to allow the additions to be pipelined, and the partition sums
+// are then added in the order of the partitions.
+func Norm[N Number](values []N) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	partitions, partitionSize := parts(values)
+	sums := make([]float64, partitions)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		values := values[start:end]
+		var s0, s1, s2, s3 float64
+		i := 0
+		for ; i+4 <= len(values); i += 4 {
+			v0, v1, v2, v3 := float64(values[i]), float64(values[i+1]), float64(values[i+2]), float64(values[i+3])
+			s0 += v0 * v0
+			s1 += v1 * v1
+			s2 += v2 * v2
+			s3 += v3 * v3
+		}
+		for ; i < len(values); i++ {
+			v := float64(values[i])
+			s0 += v * v
+		}
+		sums[p] = (s0 + s1) + (s2 + s3)
+	})
+
+	var total float64
+	for _, s := range sums {
+		total += s
+	}
+	return math.Sqrt(total)
+}
+
+// Clamp limits each of the values to the range [lo, hi] in place.
+//
+// Panics if lo is greater than hi.
+func Clamp[T Ordered](values []T, lo, hi T) {
+	if lo > hi {
+		panic("cannot clamp to a range with lo greater than hi")
+	}
+	if len(values) == 0 {
+		return
+	}
+
+	partitions, partitionSize := parts(values)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		values := values[start:end]
+		for i, v := range values {
+			if v < lo {
+				values[i] = lo
+			} else if v > hi {
+				values[i] = hi
+			}
+		}
+	})
+}
+
+// NormalizeMethod defines how Normalize rescales the values.
+type NormalizeMethod int
+
+const (
+	// NormalizeMinMax rescales the values linearly to the range [0, 1], so
+	// that the smallest value becomes 0 and the largest becomes 1.
+	NormalizeMinMax NormalizeMethod = iota
+	// NormalizeZScore rescales the values to their z-scores, so that the
+	// values have a mean of 0 and a standard deviation of 1.
+	NormalizeZScore
+)
+
+// Normalize rescales the values in place as defined by method. If all of the
+// values are equal, they are all set to 0.
+//
+// The statistics needed for the rescaling are computed in a single parallel
+// pass using Describe, then the values are rescaled in a second parallel
+// pass.
+func Normalize[F Float](values []F, method NormalizeMethod) {
+	if len(values) == 0 {
+		return
+	}
+
+	stats := Describe(values)
+	offset, scale := float64(stats.Min), float64(stats.Max-stats.Min)
+	if method == NormalizeZScore {
+		offset, scale = stats.Mean, stats.StdDev
+	}
+
+	partitions, partitionSize := parts(values)
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		values := values[start:end]
+		for i, v := range values {
+			if scale == 0 {
+				values[i] = 0
+			} else {
+				values[i] = F((float64(v) - offset) / scale)
+			}
+		}
+	})
+}
+
+// MovingAverage returns the means of each window of window consecutive
+// values, i.e. a slice where the item at index i is the mean of the values
+// from i up to but not including i+window. If there are fewer values than
+// window, an empty slice is returned.
+//
+// The output range is divided into partitions, and each partition sums its
+// first window directly and then slides the window across the rest of its
+// range, so the windows overlapping partition boundaries need no special
+// handling.
+//
+// Panics if window is less than 1.
+func MovingAverage[N Number](values []N, window int) []float64 {
+	if window < 1 {
+		panic("cannot average over a window of less than one value")
+	}
+	n := len(values) - window + 1
+	if n <= 0 {
+		return []float64(nil)
+	}
+
+	partitions, partitionSize := partsOf(n)
+	result := make([]float64, n)
+	forEachPart(partitions, partitionSize, n, func(p, start, end int) {
+		var sum float64
+		for _, v := range values[start : start+window] {
+			sum += float64(v)
+		}
+		result[start] = sum / float64(window)
+		for i := start + 1; i < end; i++ {
+			sum += float64(values[i+window-1]) - float64(values[i-1])
+			result[i] = sum / float64(window)
+		}
+	})
+
+	return result
+}
+
+// Convolve1D returns the discrete convolution of the values with the kernel,
+// including only the items for which the kernel fully overlaps the values,
+// i.e. a slice of length len(values)-len(kernel)+1 where the item at index i
+// is the sum of values[i+j]*kernel[len(kernel)-1-j] over the kernel. If there
+// are fewer values than the kernel has items, an empty slice is returned.
+//
+// The output range is divided into partitions, and each partition reads the
+// overlapping windows of the values it needs directly.
+//
+// Panics if kernel is empty.
+func Convolve1D[N Number](values, kernel []N) []N {
+	if len(kernel) == 0 {
+		panic("cannot convolve with an empty kernel")
+	}
+	n := len(values) - len(kernel) + 1
+	if n <= 0 {
+		return []N(nil)
+	}
+
+	flipped := make([]N, len(kernel))
+	for j, k := range kernel {
+		flipped[len(kernel)-1-j] = k
+	}
+
+	partitions, partitionSize := partsOf(n)
+	result := make([]N, n)
+	forEachPart(partitions, partitionSize, n, func(p, start, end int) {
+		for i := start; i < end; i++ {
+			window := values[i : i+len(flipped)]
+			var sum N
+			for j, k := range flipped {
+				sum += window[j] * k
+			}
+			result[i] = sum
+		}
+	})
+
+	return result
+}
+
+// Interpolation defines how Resample computes the values between the
+// original samples.
+type Interpolation int
+
+const (
+	// InterpolationLinear interpolates linearly between the two nearest
+	// original samples.
+	InterpolationLinear Interpolation = iota
+	// InterpolationNearest takes the value of the nearest original sample.
+	InterpolationNearest
+)
+
+// Resample returns the values resampled to newLen samples spanning the same
+// range, so that the first and last samples are kept, and the samples in
+// between are computed from the original samples as defined by interp.
+//
+// Each sample is computed independently from its position in the original
+// samples, so the samples are computed in parallel without coordination.
+//
+// Panics if newLen is negative, or if values is empty and newLen is not 0.
+func Resample[F Float](values []F, newLen int, interp Interpolation) []F {
+	if newLen < 0 {
+		panic("cannot resample to a negative length")
+	}
+	if newLen == 0 {
+		return []F(nil)
+	}
+	if len(values) == 0 {
+		panic("cannot resample an empty slice")
+	}
+
+	var step float64
+	if newLen > 1 {
+		step = float64(len(values)-1) / float64(newLen-1)
+	}
+	return Generate(newLen, func(i int) F {
+		x := float64(i) * step
+		if interp == InterpolationNearest {
+			return values[minInt(int(math.Round(x)), len(values)-1)]
+		}
+		j := minInt(int(x), len(values)-1)
+		if j == len(values)-1 {
+			return values[j]
+		}
+		frac := F(x - float64(j))
+		return values[j] + (values[j+1]-values[j])*frac
+	})
+}
+
+// MapNum returns a slice of the results of applying the transform function on
+// every item in values, as with Map, but specialized for cheap arithmetic
+// transforms of numeric values.
+//
+// Each partition transforms its values in a loop unrolled by four over
+// subslices with their bounds checks hoisted out of the loop, which reduces
+// the per-item overhead that dominates when the transform itself is cheap.
+func MapNum[N Number](values []N, transform func(N) N) []N {
+	if len(values) == 0 {
+		return []N(nil)
+	}
+
+	partitions, partitionSize := parts(values)
+	result := make([]N, len(values))
+	forEachPart(partitions, partitionSize, len(values), func(p, start, end int) {
+		src, dst := values[start:end], result[start:end]
+		dst = dst[:len(src)]
+		i := 0
+		for ; i+4 <= len(src); i += 4 {
+			s, d := src[i:i+4:i+4], dst[i:i+4:i+4]
+			d[0] = transform(s[0])
+			d[1] = transform(s[1])
+			d[2] = transform(s[2])
+			d[3] = transform(s[3])
+		}
+		for ; i < len(src); i++ {
+			dst[i] = transform(src[i])
+		}
+	})
+
+	return result
+}
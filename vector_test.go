@@ -0,0 +1,393 @@
+package par_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/jussi-kalliokoski/par"
+)
+
+func TestDot(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				a := make([]int, l)
+				b := make([]int, l)
+				var expected int
+				for i := range a {
+					a[i], b[i] = i%7-3, i%5
+					expected += a[i] * b[i]
+				}
+
+				assertEquals(t, expected, par.Dot(a, b))
+			})
+		}
+	})
+
+	t.Run("different lengths", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.Dot([]int{1, 2}, []int{1})
+		})
+	})
+}
+
+func TestAdd(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				a := make([]int, l)
+				b := make([]int, l)
+				sums := make([]int, l)
+				differences := make([]int, l)
+				products := make([]int, l)
+				for i := range a {
+					a[i], b[i] = i%7-3, i%5
+					sums[i] = a[i] + b[i]
+					differences[i] = a[i] - b[i]
+					products[i] = a[i] * b[i]
+				}
+
+				assertSliceEquals(t, sums, par.Add(a, b))
+				assertSliceEquals(t, differences, par.Sub(a, b))
+				assertSliceEquals(t, products, par.Mul(a, b))
+			})
+		}
+	})
+
+	t.Run("different lengths", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.Add([]int{1, 2}, []int{1})
+		})
+		assertPanics(t, func() {
+			par.Sub([]int{1, 2}, []int{1})
+		})
+		assertPanics(t, func() {
+			par.Mul([]int{1, 2}, []int{1})
+		})
+	})
+}
+
+func TestScale(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				expected := make([]int, l)
+				for i := range values {
+					values[i] = i%7 - 3
+					expected[i] = values[i] * -3
+				}
+
+				par.Scale(values, -3)
+
+				assertSliceEquals(t, expected, values)
+			})
+		}
+	})
+}
+
+func TestAXPY(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				x := make([]int, l)
+				y := make([]int, l)
+				expected := make([]int, l)
+				for i := range x {
+					x[i], y[i] = i%7-3, i%5
+					expected[i] = y[i] + 2*x[i]
+				}
+
+				par.AXPY(y, 2, x)
+
+				assertSliceEquals(t, expected, y)
+			})
+		}
+	})
+
+	t.Run("different lengths", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.AXPY([]int{1, 2}, 2, []int{1})
+		})
+	})
+}
+
+func TestNorm(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				var sum float64
+				for i := range values {
+					values[i] = i%7 - 3
+					sum += float64(values[i] * values[i])
+				}
+
+				assertApprox(t, math.Sqrt(sum), par.Norm(values), 1e-9)
+			})
+		}
+	})
+}
+
+func TestClamp(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				expected := make([]int, l)
+				for i := range values {
+					values[i] = i%11 - 5
+					expected[i] = maxInt(-2, minInt(3, values[i]))
+				}
+
+				par.Clamp(values, -2, 3)
+
+				assertSliceEquals(t, expected, values)
+			})
+		}
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.Clamp([]int{1, 2}, 3, 2)
+		})
+	})
+}
+
+func TestNormalize(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]float64, l)
+				for i := range values {
+					values[i] = float64(i%11)*2 + 100
+				}
+
+				t.Run("min max", func(t *testing.T) {
+					minMax := append([]float64(nil), values...)
+
+					par.Normalize(minMax, par.NormalizeMinMax)
+
+					for i, v := range minMax {
+						expected := 0.0
+						if l > 1 {
+							expected = float64(i%11) / float64(minInt(l, 11)-1)
+						}
+						assertApprox(t, expected, v, 1e-9)
+					}
+				})
+
+				t.Run("z-score", func(t *testing.T) {
+					zScore := append([]float64(nil), values...)
+
+					par.Normalize(zScore, par.NormalizeZScore)
+
+					if l > 1 {
+						assertApprox(t, 0, par.Mean(zScore), 1e-9)
+						assertApprox(t, 1, par.StdDev(zScore), 1e-9)
+					}
+				})
+			})
+		}
+	})
+
+	t.Run("constant", func(t *testing.T) {
+		values := []float64{3, 3, 3}
+
+		par.Normalize(values, par.NormalizeZScore)
+
+		assertSliceEquals(t, []float64{0, 0, 0}, values)
+	})
+}
+
+func TestMovingAverage(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			for _, window := range []int{1, 3, 10} {
+				t.Run(fmt.Sprintf("len %d window %d", l, window), func(t *testing.T) {
+					values := make([]int, l)
+					for i := range values {
+						values[i] = i%7 - 3
+					}
+					expected := []float64(nil)
+					for i := 0; i+window <= l; i++ {
+						var sum int
+						for _, v := range values[i : i+window] {
+							sum += v
+						}
+						expected = append(expected, float64(sum)/float64(window))
+					}
+
+					received := par.MovingAverage(values, window)
+
+					assertEquals(t, len(expected), len(received))
+					for i := range expected {
+						assertApprox(t, expected[i], received[i], 1e-9)
+					}
+				})
+			}
+		}
+	})
+
+	t.Run("invalid window", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.MovingAverage([]int{1, 2}, 0)
+		})
+	})
+}
+
+func TestConvolve1D(t *testing.T) {
+	kernel := []int{1, -2, 3}
+
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]int, l)
+				for i := range values {
+					values[i] = i%7 - 3
+				}
+				expected := []int(nil)
+				for i := 0; i+len(kernel) <= l; i++ {
+					expected = append(expected, 3*values[i]-2*values[i+1]+values[i+2])
+				}
+
+				assertSliceEquals(t, expected, par.Convolve1D(values, kernel))
+			})
+		}
+	})
+
+	t.Run("empty kernel", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.Convolve1D([]int{1, 2}, nil)
+		})
+	})
+}
+
+func TestResample(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(1) {
+			for _, newLen := range []int{0, 1, 2, l, 2*l - 1} {
+				t.Run(fmt.Sprintf("len %d to %d", l, newLen), func(t *testing.T) {
+					values := make([]float64, l)
+					for i := range values {
+						values[i] = float64(i) * 3
+					}
+
+					t.Run("linear", func(t *testing.T) {
+						received := par.Resample(values, newLen, par.InterpolationLinear)
+
+						assertEquals(t, newLen, len(received))
+						for i, v := range received {
+							expected := 0.0
+							if newLen > 1 {
+								expected = float64(i) * 3 * float64(l-1) / float64(newLen-1)
+							}
+							assertApprox(t, expected, v, 1e-9)
+						}
+					})
+
+					t.Run("nearest", func(t *testing.T) {
+						received := par.Resample(values, newLen, par.InterpolationNearest)
+
+						assertEquals(t, newLen, len(received))
+						for i, v := range received {
+							expected := 0.0
+							if newLen > 1 {
+								expected = 3 * math.Round(float64(i)*float64(l-1)/float64(newLen-1))
+							}
+							assertApprox(t, expected, v, 1e-9)
+						}
+					})
+				})
+			}
+		}
+	})
+
+	t.Run("negative length", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.Resample([]float64{1, 2}, -1, par.InterpolationLinear)
+		})
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.Resample([]float64(nil), 2, par.InterpolationLinear)
+		})
+	})
+}
+
+func TestMapNum(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		for _, l := range testLengths(0) {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				values := make([]float64, l)
+				expected := make([]float64, l)
+				for i := range values {
+					values[i] = float64(i)
+					expected[i] = values[i]*2 + 1
+				}
+
+				received := par.MapNum(values, func(v float64) float64 {
+					return v*2 + 1
+				})
+
+				assertSliceEquals(t, expected, received)
+			})
+		}
+	})
+}
+
+func BenchmarkMapNum(b *testing.B) {
+	values := make([]float64, 10000000)
+	for i := range values {
+		values[i] = float64(i)
+	}
+
+	b.Run("map", func(b *testing.B) {
+		var r bool
+		for n := 0; n < b.N; n++ {
+			result := par.Map(values, func(v float64) float64 {
+				return v*2 + 1
+			})
+			r = len(result) == 123
+		}
+		deadBool = r
+	})
+	b.Run("map num", func(b *testing.B) {
+		var r bool
+		for n := 0; n < b.N; n++ {
+			result := par.MapNum(values, func(v float64) float64 {
+				return v*2 + 1
+			})
+			r = len(result) == 123
+		}
+		deadBool = r
+	})
+}
+
+func BenchmarkSum(b *testing.B) {
+	values := make([]float64, 10000000)
+	for i := range values {
+		values[i] = float64(i)
+	}
+
+	b.Run("reduce", func(b *testing.B) {
+		var r bool
+		for n := 0; n < b.N; n++ {
+			result := par.Reduce(values, func(a, b float64) float64 {
+				return a + b
+			})
+			r = result == 123
+		}
+		deadBool = r
+	})
+	b.Run("sum", func(b *testing.B) {
+		var r bool
+		for n := 0; n < b.N; n++ {
+			result := par.Sum(values)
+			r = result == 123
+		}
+		deadBool = r
+	})
+}
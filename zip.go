@@ -0,0 +1,56 @@
+package par
+
+// Pair holds two values of possibly different types.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Zip returns a slice of pairs where each pair holds the items at the same
+// index in a and b.
+//
+// The implementation is deterministic, and the returned slice maintains the
+// order of the original values.
+//
+// Panics if a and b are of different lengths.
+func Zip[A, B any](a []A, b []B) []Pair[A, B] {
+	if len(a) != len(b) {
+		panic("cannot zip slices of different lengths")
+	}
+	if len(a) == 0 {
+		return []Pair[A, B](nil)
+	}
+
+	partitions, partitionSize := parts(a)
+	result := make([]Pair[A, B], len(a))
+	forEachPart(partitions, partitionSize, len(a), func(p, start, end int) {
+		for i := start; i < end; i++ {
+			result[i] = Pair[A, B]{a[i], b[i]}
+		}
+	})
+
+	return result
+}
+
+// Unzip returns two slices, the first holding the first values of each pair
+// and the second holding the second values of each pair.
+//
+// The implementation is deterministic, and the returned slices maintain the
+// order of the original pairs.
+func Unzip[A, B any](pairs []Pair[A, B]) ([]A, []B) {
+	if len(pairs) == 0 {
+		return []A(nil), []B(nil)
+	}
+
+	partitions, partitionSize := parts(pairs)
+	a := make([]A, len(pairs))
+	b := make([]B, len(pairs))
+	forEachPart(partitions, partitionSize, len(pairs), func(p, start, end int) {
+		for i := start; i < end; i++ {
+			a[i] = pairs[i].First
+			b[i] = pairs[i].Second
+		}
+	})
+
+	return a, b
+}
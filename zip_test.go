@@ -0,0 +1,72 @@
+package par_test
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/jussi-kalliokoski/par"
+)
+
+func TestZip(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		tests := []int(nil)
+		for i := 0; i < 128; i++ {
+			tests = append(tests, i)
+		}
+		for i := 128; i < 2048; i = i << 1 {
+			tests = append(tests, i)
+		}
+		for _, l := range tests {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				a := make([]int, l)
+				b := make([]string, l)
+				expected := make([]par.Pair[int, string], l)
+				for i := range a {
+					a[i] = i
+					b[i] = strconv.Itoa(i)
+					expected[i] = par.Pair[int, string]{First: i, Second: strconv.Itoa(i)}
+				}
+
+				received := par.Zip(a, b)
+
+				assertSliceEquals(t, expected, received)
+			})
+		}
+	})
+
+	t.Run("different lengths", func(t *testing.T) {
+		assertPanics(t, func() {
+			par.Zip([]int{1, 2}, []string{"1"})
+		})
+	})
+}
+
+func TestUnzip(t *testing.T) {
+	t.Run("lengths", func(t *testing.T) {
+		tests := []int(nil)
+		for i := 0; i < 128; i++ {
+			tests = append(tests, i)
+		}
+		for i := 128; i < 2048; i = i << 1 {
+			tests = append(tests, i)
+		}
+		for _, l := range tests {
+			t.Run(fmt.Sprintf("len %d", l), func(t *testing.T) {
+				pairs := make([]par.Pair[int, string], l)
+				expectedA := make([]int, l)
+				expectedB := make([]string, l)
+				for i := range pairs {
+					pairs[i] = par.Pair[int, string]{First: i, Second: strconv.Itoa(i)}
+					expectedA[i] = i
+					expectedB[i] = strconv.Itoa(i)
+				}
+
+				receivedA, receivedB := par.Unzip(pairs)
+
+				assertSliceEquals(t, expectedA, receivedA)
+				assertSliceEquals(t, expectedB, receivedB)
+			})
+		}
+	})
+}